@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/r33ta/pc-database-manager/internal/config"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/migrate"
+	"github.com/r33ta/pc-database-manager/internal/storage/postgres"
+	"github.com/r33ta/pc-database-manager/internal/storage/sqlite"
+)
+
+func main() {
+	var down int
+	var status bool
+
+	flag.IntVar(&down, "down", -1, "revert migrations down to this version (0 reverts everything)")
+	flag.BoolVar(&status, "status", false, "print the current and latest schema versions and exit")
+	flag.Parse()
+
+	cfg := config.MustLoad()
+	ctx := context.Background()
+
+	m, closeStorage, err := setupMigrator(cfg)
+	if err != nil {
+		log.Fatalf("failed to set up migrations: %s", err)
+	}
+	defer closeStorage()
+
+	switch {
+	case status:
+		version, err := m.Version(ctx)
+		if err != nil {
+			log.Fatalf("failed to get schema version: %s", err)
+		}
+		fmt.Printf("current version: %d, latest version: %d\n", version, m.Latest())
+		return
+	case down >= 0:
+		if err := m.Down(ctx, down); err != nil {
+			log.Fatalf("failed to revert migrations: %s", err)
+		}
+	default:
+		if err := m.Up(ctx); err != nil {
+			log.Fatalf("failed to apply migrations: %s", err)
+		}
+	}
+
+	version, err := m.Version(ctx)
+	if err != nil {
+		log.Fatalf("failed to get schema version: %s", err)
+	}
+
+	fmt.Printf("schema is at version %d\n", version)
+}
+
+// setupMigrator opens the configured storage without migrating it and returns
+// its migrator together with a function closing the storage.
+func setupMigrator(cfg *config.Config) (*migrate.Migrator, func() error, error) {
+	switch cfg.StorageDriver {
+	case storage.DriverSQLite:
+		s, err := sqlite.Open(cfg.StoragePath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		m, err := s.Migrator()
+		if err != nil {
+			s.Close()
+			return nil, nil, err
+		}
+
+		return m, s.Close, nil
+	case storage.DriverPostgres:
+		s, err := postgres.Open(cfg.StorageDSN)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		m, err := s.Migrator()
+		if err != nil {
+			s.Close()
+			return nil, nil, err
+		}
+
+		return m, s.Close, nil
+	case storage.DriverMemory:
+		return nil, nil, fmt.Errorf("storage driver %q has no schema to migrate", cfg.StorageDriver)
+	default:
+		return nil, nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
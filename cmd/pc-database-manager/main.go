@@ -1,21 +1,71 @@
 package main
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
 	"github.com/r33ta/pc-database-manager/internal/config"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/build/checkbuild"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/build/savebuild"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/case/getcase"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/case/savecase"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cooler/getcooler"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cooler/savecooler"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/deletecpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/getcpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/listcpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/patchcpu"
 	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/savecpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/updatecpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/deletegpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/getgpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/listgpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/patchgpu"
 	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/savegpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/updategpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/deletememory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/getmemory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/listmemory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/patchmemory"
 	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/savememory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/updatememory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/motherboard/getmotherboard"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/motherboard/savemotherboard"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/checkpc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/deletepc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/getpc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/getpower"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/listpc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/patchpc"
 	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/savepc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/updatepc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/deleteperipheral"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/getperipheral"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/listperipheral"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/patchperipheral"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/saveperipheral"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/updateperipheral"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/psu/getpsu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/psu/savepsu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/deleteram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/getram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/listram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/patchram"
 	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/saveram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/updateram"
 	mwLogger "github.com/r33ta/pc-database-manager/internal/http-server/middleware/logger"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/handlers/slogpretty"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/services/compatibility"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/memstore"
+	"github.com/r33ta/pc-database-manager/internal/storage/postgres"
 	"github.com/r33ta/pc-database-manager/internal/storage/sqlite"
 )
 
@@ -37,12 +87,17 @@ func main() {
 	)
 	log.Debug("debug messages are enabled")
 
-	storage, err := sqlite.New(cfg.StoragePath)
+	repo, err := setupStorage(cfg)
 	if err != nil {
 		log.Error("failed to init storage", sl.Err(err))
 		os.Exit(1)
 	}
 
+	checker := compatibility.New(compatibility.DefaultRules...)
+
+	// errors go out as problem details to clients accepting them
+	render.Respond = resp.Respond
+
 	router := chi.NewRouter()
 
 	router.Use(middleware.RequestID)
@@ -50,12 +105,64 @@ func main() {
 	router.Use(mwLogger.New(log))
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
-
-	router.Post("/save/pc", savepc.New(log, storage))
-	router.Post("/save/ram", saveram.New(log, storage))
-	router.Post("/save/cpu", savecpu.New(log, storage))
-	router.Post("/save/gpu", savegpu.New(log, storage))
-	router.Post("/save/memory", savememory.New(log, storage))
+	// bound request contexts so slow storage calls are aborted instead of
+	// outliving the write timeout
+	router.Use(middleware.Timeout(cfg.HTTPServer.Timeout))
+
+	router.Post("/save/pc", savepc.New(log, repo))
+	router.Post("/save/ram", saveram.New(log, repo))
+	router.Post("/save/cpu", savecpu.New(log, repo))
+	router.Post("/save/gpu", savegpu.New(log, repo))
+	router.Post("/save/memory", savememory.New(log, repo))
+	router.Post("/save/motherboard", savemotherboard.New(log, repo))
+	router.Post("/save/psu", savepsu.New(log, repo))
+	router.Post("/save/case", savecase.New(log, repo))
+	router.Post("/save/cooler", savecooler.New(log, repo))
+	router.Post("/save/peripheral", saveperipheral.New(log, repo))
+
+	router.Post("/builds", savebuild.New(log, repo))
+	router.Post("/builds/compatibility", checkbuild.New(log, repo, checker))
+	router.Post("/pc/{id}/compatibility", checkpc.New(log, repo, checker))
+	router.Get("/pc/{id}/power", getpower.New(log, repo, cfg.Power.Headroom))
+
+	router.Get("/pc", listpc.New(log, repo))
+	router.Get("/ram", listram.New(log, repo))
+	router.Get("/cpu", listcpu.New(log, repo))
+	router.Get("/gpu", listgpu.New(log, repo))
+	router.Get("/memory", listmemory.New(log, repo))
+	router.Get("/peripheral", listperipheral.New(log, repo))
+
+	router.Get("/pc/{id}", getpc.New(log, repo))
+	router.Get("/ram/{id}", getram.New(log, repo))
+	router.Get("/cpu/{id}", getcpu.New(log, repo))
+	router.Get("/gpu/{id}", getgpu.New(log, repo))
+	router.Get("/memory/{id}", getmemory.New(log, repo))
+	router.Get("/motherboard/{id}", getmotherboard.New(log, repo))
+	router.Get("/psu/{id}", getpsu.New(log, repo))
+	router.Get("/case/{id}", getcase.New(log, repo))
+	router.Get("/cooler/{id}", getcooler.New(log, repo))
+	router.Get("/peripheral/{id}", getperipheral.New(log, repo))
+
+	router.Put("/pc/{id}", updatepc.New(log, repo))
+	router.Put("/ram/{id}", updateram.New(log, repo))
+	router.Put("/cpu/{id}", updatecpu.New(log, repo))
+	router.Put("/gpu/{id}", updategpu.New(log, repo))
+	router.Put("/memory/{id}", updatememory.New(log, repo))
+	router.Put("/peripheral/{id}", updateperipheral.New(log, repo))
+
+	router.Patch("/pc/{id}", patchpc.New(log, repo))
+	router.Patch("/ram/{id}", patchram.New(log, repo))
+	router.Patch("/cpu/{id}", patchcpu.New(log, repo))
+	router.Patch("/gpu/{id}", patchgpu.New(log, repo))
+	router.Patch("/memory/{id}", patchmemory.New(log, repo))
+	router.Patch("/peripheral/{id}", patchperipheral.New(log, repo))
+
+	router.Delete("/pc/{id}", deletepc.New(log, repo))
+	router.Delete("/ram/{id}", deleteram.New(log, repo))
+	router.Delete("/cpu/{id}", deletecpu.New(log, repo))
+	router.Delete("/gpu/{id}", deletegpu.New(log, repo))
+	router.Delete("/memory/{id}", deletememory.New(log, repo))
+	router.Delete("/peripheral/{id}", deleteperipheral.New(log, repo))
 
 	// Start server
 
@@ -77,6 +184,19 @@ func main() {
 	log.Error("server stopped")
 }
 
+func setupStorage(cfg *config.Config) (storage.Repository, error) {
+	switch cfg.StorageDriver {
+	case storage.DriverSQLite:
+		return sqlite.New(cfg.StoragePath)
+	case storage.DriverPostgres:
+		return postgres.New(cfg.StorageDSN)
+	case storage.DriverMemory:
+		return memstore.New(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+}
+
 func setupLogger(env string) *slog.Logger {
 	var log *slog.Logger
 	switch env {
@@ -6,12 +6,16 @@ import (
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Config struct {
-	Env         string `yaml:"env" env-default:"local"`
-	StoragePath string `yaml:"storage_path" env-required:"true"`
-	HTTPServer  `yaml:"http_server"`
+	Env           string `yaml:"env" env-default:"local"`
+	StorageDriver string `yaml:"storage_driver" env-default:"sqlite"`
+	StoragePath   string `yaml:"storage_path"`
+	StorageDSN    string `yaml:"storage_dsn" env:"STORAGE_DSN"`
+	HTTPServer    `yaml:"http_server"`
+	Power         `yaml:"power"`
 }
 
 type HTTPServer struct {
@@ -20,6 +24,12 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
 }
 
+type Power struct {
+	// Headroom is the share added on top of the estimated draw of a pc,
+	// 0.2 means 20%.
+	Headroom float64 `yaml:"headroom" env-default:"0.2"`
+}
+
 func MustLoad() *Config {
 	configPath := os.Getenv("CONFIG_PATH")
 	if configPath == "" {
@@ -37,5 +47,14 @@ func MustLoad() *Config {
 		log.Fatalf("config file error: %s", err)
 	}
 
+	switch {
+	case cfg.StorageDriver == storage.DriverSQLite && cfg.StoragePath == "":
+		log.Fatal("storage_path is required for the sqlite storage driver")
+	case cfg.StorageDriver == storage.DriverPostgres && cfg.StorageDSN == "":
+		log.Fatal("storage_dsn is required for the postgres storage driver")
+	case cfg.Power.Headroom < 0 || cfg.Power.Headroom > 1:
+		log.Fatal("power.headroom must be between 0 and 1")
+	}
+
 	return &cfg
 }
@@ -0,0 +1,204 @@
+package checkbuild
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/build/savebuild"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/services/compatibility"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Compatibility *compatibility.Report `json:"compatibility,omitempty"`
+}
+
+// ComponentGetter resolves the components a proposed build references by id.
+type ComponentGetter interface {
+	GetCPU(ctx context.Context, id int64) (*cpu.CPU, error)
+	GetRAM(ctx context.Context, id int64) (*ram.RAM, error)
+	GetGPU(ctx context.Context, id int64) (*gpu.GPU, error)
+	GetMemory(ctx context.Context, id int64) (*memory.Memory, error)
+	GetMotherboard(ctx context.Context, id int64) (*motherboard.Motherboard, error)
+	GetPSU(ctx context.Context, id int64) (*psu.PSU, error)
+	GetCase(ctx context.Context, id int64) (*pccase.Case, error)
+	GetCooler(ctx context.Context, id int64) (*cooler.Cooler, error)
+}
+
+// New checks a proposed build without saving it. It takes the same payload
+// as POST /builds.
+func New(log *slog.Logger, componentGetter ComponentGetter, checker *compatibility.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.checkbuild.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req savebuild.RequestBuild
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		b, err := expand(r.Context(), componentGetter, req.ToBuild())
+
+		if errors.Is(err, storage.ErrComponentNotFound) {
+			log.Info("build component not found", sl.Err(err))
+
+			render.Render(w, r, resp.ComponentNotFound(err))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to load build components", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to load build components"))
+
+			return
+		}
+
+		report := checker.Check(b)
+
+		log.Info("build checked",
+			slog.Int("errors", len(report.Errors)),
+			slog.Int("warnings", len(report.Warnings)),
+		)
+
+		render.JSON(w, r, Response{
+			Response:      resp.OK(),
+			Compatibility: &report,
+		})
+	}
+}
+
+// expand replaces the components of b given by id with the stored ones.
+func expand(ctx context.Context, g ComponentGetter, b pc.Build) (*pc.Expanded, error) {
+	res := pc.Expanded{
+		Name:        b.Name,
+		CPU:         b.CPU,
+		Motherboard: b.Motherboard,
+		PSU:         b.PSU,
+		Case:        b.Case,
+		Cooler:      b.Cooler,
+	}
+	var err error
+
+	if b.CPU.ID != 0 {
+		c, err := load(ctx, g.GetCPU, storage.ErrCPUNotFound, "cpu", b.CPU.ID)
+		if err != nil {
+			return nil, err
+		}
+		res.CPU = *c
+	}
+	for _, part := range b.RAM {
+		if part.ID != 0 {
+			r, err := load(ctx, g.GetRAM, storage.ErrRAMNotFound, "ram", part.ID)
+			if err != nil {
+				return nil, err
+			}
+			part.RAM = *r
+		}
+		res.RAM = append(res.RAM, part)
+	}
+	for _, part := range b.GPU {
+		if part.ID != 0 {
+			r, err := load(ctx, g.GetGPU, storage.ErrGPUNotFound, "gpu", part.ID)
+			if err != nil {
+				return nil, err
+			}
+			part.GPU = *r
+		}
+		res.GPU = append(res.GPU, part)
+	}
+	for _, part := range b.Memory {
+		if part.ID != 0 {
+			r, err := load(ctx, g.GetMemory, storage.ErrMemoryNotFound, "memory", part.ID)
+			if err != nil {
+				return nil, err
+			}
+			part.Memory = *r
+		}
+		res.Memory = append(res.Memory, part)
+	}
+
+	if b.Motherboard != nil && b.Motherboard.ID != 0 {
+		if res.Motherboard, err = load(ctx, g.GetMotherboard, storage.ErrMotherboardNotFound, "motherboard", b.Motherboard.ID); err != nil {
+			return nil, err
+		}
+	}
+	if b.PSU != nil && b.PSU.ID != 0 {
+		if res.PSU, err = load(ctx, g.GetPSU, storage.ErrPSUNotFound, "psu", b.PSU.ID); err != nil {
+			return nil, err
+		}
+	}
+	if b.Case != nil && b.Case.ID != 0 {
+		if res.Case, err = load(ctx, g.GetCase, storage.ErrCaseNotFound, "case", b.Case.ID); err != nil {
+			return nil, err
+		}
+	}
+	if b.Cooler != nil && b.Cooler.ID != 0 {
+		if res.Cooler, err = load(ctx, g.GetCooler, storage.ErrCoolerNotFound, "cooler", b.Cooler.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &res, nil
+}
+
+// load gets a component by id and reports a missing one the same way
+// saving the build would.
+func load[T any](ctx context.Context, get func(context.Context, int64) (*T, error), notFound error, component string, id int64) (*T, error) {
+	res, err := get(ctx, id)
+	if errors.Is(err, notFound) {
+		return nil, &storage.ComponentNotFoundError{Component: component, ID: id}
+	}
+
+	return res, err
+}
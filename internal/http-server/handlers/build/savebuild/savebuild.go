@@ -0,0 +1,235 @@
+package savebuild
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/case/savecase"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cooler/savecooler"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/savecpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/savegpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/savememory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/motherboard/savemotherboard"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/psu/savepsu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/saveram"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	PC *pc.PC `json:"pc,omitempty"`
+}
+
+// RequestBuild takes the cpu and every part either as an existing id or as
+// a new component to be created, but not both. A new component matching a
+// stored one uses the stored one.
+type RequestBuild struct {
+	Name   string              `json:"name" validate:"required"`
+	CPUID  int64               `json:"cpu_id" validate:"required_without=CPU,excluded_with=CPU"`
+	CPU    *savecpu.RequestCPU `json:"cpu" validate:"required_without=CPUID"`
+	RAM    []RequestRAMPart    `json:"ram" validate:"required,min=1,dive"`
+	GPU    []RequestGPUPart    `json:"gpu" validate:"required,min=1,dive"`
+	Memory []RequestMemoryPart `json:"memory" validate:"required,min=1,dive"`
+
+	MotherboardID int64                               `json:"motherboard_id" validate:"excluded_with=Motherboard"`
+	Motherboard   *savemotherboard.RequestMotherboard `json:"motherboard"`
+	PSUID         int64                               `json:"psu_id" validate:"excluded_with=PSU"`
+	PSU           *savepsu.RequestPSU                 `json:"psu"`
+	CaseID        int64                               `json:"case_id" validate:"excluded_with=Case"`
+	Case          *savecase.RequestCase               `json:"case"`
+	CoolerID      int64                               `json:"cooler_id" validate:"excluded_with=Cooler"`
+	Cooler        *savecooler.RequestCooler           `json:"cooler"`
+}
+
+// The part requests hold either the id of an existing component or the
+// fields of a new one, next to the quantity and slot of the part.
+
+type RequestRAMPart struct {
+	ID                  int64 `json:"id" validate:"required_without=RequestRAM,excluded_with=RequestRAM"`
+	*saveram.RequestRAM `validate:"required_without=ID"`
+	Quantity            int64  `json:"quantity" validate:"omitempty,min=1"`
+	Slot                string `json:"slot"`
+}
+
+type RequestGPUPart struct {
+	ID                  int64 `json:"id" validate:"required_without=RequestGPU,excluded_with=RequestGPU"`
+	*savegpu.RequestGPU `validate:"required_without=ID"`
+	Quantity            int64  `json:"quantity" validate:"omitempty,min=1"`
+	Slot                string `json:"slot"`
+}
+
+type RequestMemoryPart struct {
+	ID                        int64 `json:"id" validate:"required_without=RequestMemory,excluded_with=RequestMemory"`
+	*savememory.RequestMemory `validate:"required_without=ID"`
+	Quantity                  int64  `json:"quantity" validate:"omitempty,min=1"`
+	Slot                      string `json:"slot"`
+}
+
+type BuildSaver interface {
+	SaveBuild(ctx context.Context, b pc.Build) (*pc.PC, error)
+}
+
+func New(log *slog.Logger, buildSaver BuildSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.savebuild.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req RequestBuild
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		p, err := buildSaver.SaveBuild(r.Context(), req.ToBuild())
+		if errors.Is(err, storage.ErrPCAlreadyExists) {
+			log.Info("pc already exists", sl.Err(err))
+
+			render.Render(w, r, resp.AlreadyExists("pc already exists"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrComponentNotFound) {
+			log.Info("pc component not found", sl.Err(err))
+
+			render.Render(w, r, resp.ComponentNotFound(err))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save build", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to save build"))
+
+			return
+		}
+
+		log.Info("build saved", slog.Int64("id", p.ID))
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			PC:       p,
+		})
+	}
+}
+
+// ToBuild converts the request into a build, components given by id only
+// carry their id.
+func (req RequestBuild) ToBuild() pc.Build {
+	b := pc.Build{
+		Name: req.Name,
+		CPU:  cpu.CPU{ID: req.CPUID},
+	}
+
+	if req.CPU != nil {
+		b.CPU = req.CPU.ToCPU(0)
+	}
+	for _, r := range req.RAM {
+		part := pc.RAMPart{RAM: ram.RAM{ID: r.ID}, Quantity: quantity(r.Quantity), Slot: r.Slot}
+		if r.RequestRAM != nil {
+			part.RAM = r.RequestRAM.ToRAM(0)
+		}
+		b.RAM = append(b.RAM, part)
+	}
+	for _, g := range req.GPU {
+		part := pc.GPUPart{GPU: gpu.GPU{ID: g.ID}, Quantity: quantity(g.Quantity), Slot: g.Slot}
+		if g.RequestGPU != nil {
+			part.GPU = g.RequestGPU.ToGPU(0)
+		}
+		b.GPU = append(b.GPU, part)
+	}
+	for _, m := range req.Memory {
+		part := pc.MemoryPart{Memory: memory.Memory{ID: m.ID}, Quantity: quantity(m.Quantity), Slot: m.Slot}
+		if m.RequestMemory != nil {
+			part.Memory = m.RequestMemory.ToMemory(0)
+		}
+		b.Memory = append(b.Memory, part)
+	}
+
+	if req.MotherboardID != 0 {
+		b.Motherboard = &motherboard.Motherboard{ID: req.MotherboardID}
+	}
+	if req.Motherboard != nil {
+		m := req.Motherboard.ToMotherboard(0)
+		b.Motherboard = &m
+	}
+	if req.PSUID != 0 {
+		b.PSU = &psu.PSU{ID: req.PSUID}
+	}
+	if req.PSU != nil {
+		p := req.PSU.ToPSU(0)
+		b.PSU = &p
+	}
+	if req.CaseID != 0 {
+		b.Case = &pccase.Case{ID: req.CaseID}
+	}
+	if req.Case != nil {
+		c := req.Case.ToCase(0)
+		b.Case = &c
+	}
+	if req.CoolerID != 0 {
+		b.Cooler = &cooler.Cooler{ID: req.CoolerID}
+	}
+	if req.Cooler != nil {
+		c := req.Cooler.ToCooler(0)
+		b.Cooler = &c
+	}
+
+	return b
+}
+
+// quantity defaults an omitted quantity to a single component.
+func quantity(q int64) int64 {
+	if q == 0 {
+		return 1
+	}
+	return q
+}
@@ -0,0 +1,82 @@
+package getcase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Case *pccase.Case `json:"case,omitempty"`
+}
+
+type CaseGetter interface {
+	GetCase(ctx context.Context, id int64) (*pccase.Case, error)
+}
+
+func New(log *slog.Logger, caseGetter CaseGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.getcase.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		res, err := caseGetter.GetCase(r.Context(), id)
+		if errors.Is(err, storage.ErrCaseNotFound) {
+			log.Info("case not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("case not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get case", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get case"))
+
+			return
+		}
+
+		log.Info("case found", slog.Int64("id", id))
+
+		responseOK(w, r, res)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *pccase.Case) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		Case:     res,
+	})
+}
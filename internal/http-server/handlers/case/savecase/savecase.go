@@ -0,0 +1,118 @@
+package savecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	ID int64 `json:"id,omitempty"`
+}
+
+type RequestCase struct {
+	Name            string `json:"name" validate:"required"`
+	FormFactor      string `json:"form_factor" validate:"required,oneof=E-ATX ATX Micro-ATX Mini-ITX"`
+	MaxGPULength    int64  `json:"max_gpu_length" validate:"min=0"`
+	MaxCoolerHeight int64  `json:"max_cooler_height" validate:"min=0"`
+}
+
+// ToCase converts the request into a case with the given id.
+func (req RequestCase) ToCase(id int64) pccase.Case {
+	return pccase.Case{
+		ID:              id,
+		Name:            req.Name,
+		FormFactor:      req.FormFactor,
+		MaxGPULength:    req.MaxGPULength,
+		MaxCoolerHeight: req.MaxCoolerHeight,
+	}
+}
+
+type CaseSaver interface {
+	SaveCase(ctx context.Context, c pccase.Case) (int64, error)
+}
+
+func New(log *slog.Logger, caseSaver CaseSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.savecase.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req RequestCase
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		id, err := caseSaver.SaveCase(r.Context(), req.ToCase(0))
+		if errors.Is(err, storage.ErrCaseAlreadyExists) {
+			log.Info("case already exists", slog.Int64("id", id))
+
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("case already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save case", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to save case"))
+
+			return
+		}
+
+		log.Info("case saved", slog.Int64("id", id))
+
+		responseOK(w, r, id)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		ID:       id,
+	})
+}
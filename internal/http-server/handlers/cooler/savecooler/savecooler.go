@@ -0,0 +1,118 @@
+package savecooler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	ID int64 `json:"id,omitempty"`
+}
+
+type RequestCooler struct {
+	Name   string `json:"name" validate:"required"`
+	Type   string `json:"type" validate:"required,oneof=air liquid"`
+	TDP    int64  `json:"tdp" validate:"required,min=1"`
+	Height int64  `json:"height" validate:"min=0"`
+}
+
+// ToCooler converts the request into a cooler with the given id.
+func (req RequestCooler) ToCooler(id int64) cooler.Cooler {
+	return cooler.Cooler{
+		ID:     id,
+		Name:   req.Name,
+		Type:   req.Type,
+		TDP:    req.TDP,
+		Height: req.Height,
+	}
+}
+
+type CoolerSaver interface {
+	SaveCooler(ctx context.Context, c cooler.Cooler) (int64, error)
+}
+
+func New(log *slog.Logger, coolerSaver CoolerSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.savecooler.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req RequestCooler
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		id, err := coolerSaver.SaveCooler(r.Context(), req.ToCooler(0))
+		if errors.Is(err, storage.ErrCoolerAlreadyExists) {
+			log.Info("cooler already exists", slog.Int64("id", id))
+
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("cooler already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save cooler", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to save cooler"))
+
+			return
+		}
+
+		log.Info("cooler saved", slog.Int64("id", id))
+
+		responseOK(w, r, id)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		ID:       id,
+	})
+}
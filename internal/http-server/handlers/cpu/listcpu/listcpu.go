@@ -0,0 +1,113 @@
+package listcpu
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/listquery"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type CPULister interface {
+	ListCPU(ctx context.Context, filter storage.CPUFilter, opts storage.ListOptions) ([]cpu.CPU, string, error)
+}
+
+func New(log *slog.Logger, cpuLister CPULister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.listcpu.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		q := r.URL.Query()
+
+		opts, err := listquery.Options(q)
+		if err != nil {
+			log.Error("invalid list options", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		filter, err := parseFilter(q)
+		if err != nil {
+			log.Error("invalid filter", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		items, next, err := cpuLister.ListCPU(r.Context(), filter, opts)
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid cursor"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidSort) {
+			log.Info("invalid sort field", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid sort field"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to list cpu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to list cpu"))
+
+			return
+		}
+
+		log.Info("cpu listed", slog.Int("count", len(items)))
+
+		render.JSON(w, r, resp.NewPage(items, next))
+	}
+}
+
+func parseFilter(q url.Values) (storage.CPUFilter, error) {
+	filter := storage.CPUFilter{
+		Name:   q.Get("name"),
+		Vendor: q.Get("vendor"),
+		Socket: q.Get("socket"),
+	}
+	var err error
+
+	if filter.Cores, err = listquery.Int64(q, "cores"); err != nil {
+		return filter, err
+	}
+	if filter.Threads, err = listquery.Int64(q, "threads"); err != nil {
+		return filter, err
+	}
+	if filter.BaseClock, err = listquery.Hertz(q, "base_clock", "MHz"); err != nil {
+		return filter, err
+	}
+	if filter.TDP, err = listquery.Int64(q, "tdp"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
@@ -1,6 +1,7 @@
 package savecpu
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -8,63 +9,121 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
 	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
 	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Response struct {
 	resp.Response
+	ID int64 `json:"id,omitempty"`
 }
 
 type RequestCPU struct {
-	Name      string `json:"name" validate:"required"`
-	Cores     int64  `json:"cores" validate:"required"`
-	Threads   int64  `json:"threads" validate:"required"`
-	Frequency int64  `json:"frequency" validate:"required"`
+	Name               string      `json:"name" validate:"required"`
+	Vendor             string      `json:"vendor" validate:"omitempty,cpu_vendor"`
+	Socket             string      `json:"socket"`
+	Cores              int64       `json:"cores" validate:"required,min=1"`
+	Threads            int64       `json:"threads" validate:"required,gtefield=Cores"`
+	Architecture       string      `json:"architecture"`
+	BaseClock          units.Hertz `json:"base_clock" validate:"required,min=1" unit:"MHz"`
+	BoostClock         units.Hertz `json:"boost_clock" validate:"omitempty,gtefield=BaseClock" unit:"MHz"`
+	L3Cache            units.Bytes `json:"l3_cache" validate:"min=0" unit:"MiB"`
+	TDP                int64       `json:"tdp" validate:"min=0"`
+	IntegratedGraphics bool        `json:"integrated_graphics"`
+}
+
+// ToCPU converts the request into a cpu with the given id.
+func (req RequestCPU) ToCPU(id int64) cpu.CPU {
+	return cpu.CPU{
+		ID:                 id,
+		Name:               req.Name,
+		Vendor:             req.Vendor,
+		Socket:             req.Socket,
+		Cores:              req.Cores,
+		Threads:            req.Threads,
+		Architecture:       req.Architecture,
+		BaseClock:          req.BaseClock,
+		BoostClock:         req.BoostClock,
+		L3Cache:            req.L3Cache,
+		TDP:                req.TDP,
+		IntegratedGraphics: req.IntegratedGraphics,
+	}
+}
+
+// FromCPU is the inverse of ToCPU.
+func FromCPU(c cpu.CPU) RequestCPU {
+	return RequestCPU{
+		Name:               c.Name,
+		Vendor:             c.Vendor,
+		Socket:             c.Socket,
+		Cores:              c.Cores,
+		Threads:            c.Threads,
+		Architecture:       c.Architecture,
+		BaseClock:          c.BaseClock,
+		BoostClock:         c.BoostClock,
+		L3Cache:            c.L3Cache,
+		TDP:                c.TDP,
+		IntegratedGraphics: c.IntegratedGraphics,
+	}
 }
 
 type CPUSaver interface {
-	SaveCPU(name string, cores, threads, frequency int64) (int64, error)
+	SaveCPU(ctx context.Context, c cpu.CPU) (int64, error)
 }
 
 func New(log *slog.Logger, cpuSaver CPUSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.savecpu.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		var req RequestCPU
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request body"))
+			render.Render(w, r, resp.DecodeError(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.Render(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		id, err := cpuSaver.SaveCPU(req.Name, req.Cores, req.Threads, req.Frequency)
+		id, err := cpuSaver.SaveCPU(r.Context(), req.ToCPU(0))
 		if errors.Is(err, storage.ErrCPUAlreadyExists) {
 			log.Info("cpu already exists", slog.Int64("id", id))
 
-			render.JSON(w, r, resp.Error("cpu already exists"))
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("cpu already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
 
 			return
 		}
@@ -72,19 +131,20 @@ func New(log *slog.Logger, cpuSaver CPUSaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to save cpu", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to save cpu"))
+			render.Render(w, r, resp.StorageError("failed to save cpu"))
 
 			return
 		}
 
 		log.Info("cpu saved", slog.Int64("id", id))
 
-		responseOK(w, r)
+		responseOK(w, r, id)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request) {
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
+		ID:       id,
 	})
 }
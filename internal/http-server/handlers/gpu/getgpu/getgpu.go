@@ -0,0 +1,82 @@
+package getgpu
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	GPU *gpu.GPU `json:"gpu,omitempty"`
+}
+
+type GPUGetter interface {
+	GetGPU(ctx context.Context, id int64) (*gpu.GPU, error)
+}
+
+func New(log *slog.Logger, gpuGetter GPUGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.getgpu.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		res, err := gpuGetter.GetGPU(r.Context(), id)
+		if errors.Is(err, storage.ErrGPUNotFound) {
+			log.Info("gpu not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("gpu not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get gpu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get gpu"))
+
+			return
+		}
+
+		log.Info("gpu found", slog.Int64("id", id))
+
+		responseOK(w, r, res)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *gpu.GPU) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		GPU:      res,
+	})
+}
@@ -0,0 +1,111 @@
+package listgpu
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/listquery"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type GPULister interface {
+	ListGPU(ctx context.Context, filter storage.GPUFilter, opts storage.ListOptions) ([]gpu.GPU, string, error)
+}
+
+func New(log *slog.Logger, gpuLister GPULister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.listgpu.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		q := r.URL.Query()
+
+		opts, err := listquery.Options(q)
+		if err != nil {
+			log.Error("invalid list options", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		filter, err := parseFilter(q)
+		if err != nil {
+			log.Error("invalid filter", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		items, next, err := gpuLister.ListGPU(r.Context(), filter, opts)
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid cursor"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidSort) {
+			log.Info("invalid sort field", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid sort field"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to list gpu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to list gpu"))
+
+			return
+		}
+
+		log.Info("gpu listed", slog.Int("count", len(items)))
+
+		render.JSON(w, r, resp.NewPage(items, next))
+	}
+}
+
+func parseFilter(q url.Values) (storage.GPUFilter, error) {
+	filter := storage.GPUFilter{
+		Name:         q.Get("name"),
+		ChipVendor:   q.Get("chip_vendor"),
+		Manufacturer: q.Get("manufacturer"),
+		MemoryType:   q.Get("memory_type"),
+	}
+	var err error
+
+	if filter.Memory, err = listquery.Bytes(q, "memory", "GiB"); err != nil {
+		return filter, err
+	}
+	if filter.Frequency, err = listquery.Hertz(q, "frequency", "MHz"); err != nil {
+		return filter, err
+	}
+	if filter.Length, err = listquery.Int64(q, "length"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,174 @@
+package patchgpu
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/savegpu"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/mergepatch"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	GPU *gpu.GPU `json:"gpu,omitempty"`
+}
+
+type GPUPatcher interface {
+	GetGPU(ctx context.Context, id int64) (*gpu.GPU, error)
+	UpdateGPU(ctx context.Context, g gpu.GPU) error
+}
+
+func New(log *slog.Logger, gpuPatcher GPUPatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.patchgpu.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("failed to read request body"))
+
+			return
+		}
+
+		current, err := gpuPatcher.GetGPU(r.Context(), id)
+		if errors.Is(err, storage.ErrGPUNotFound) {
+			log.Info("gpu not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("gpu not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get gpu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get gpu"))
+
+			return
+		}
+
+		original, err := json.Marshal(savegpu.FromGPU(*current))
+		if err != nil {
+			log.Error("failed to encode gpu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to patch gpu"))
+
+			return
+		}
+
+		patched, err := mergepatch.Apply(original, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		var req savegpu.RequestGPU
+
+		if err := request.Unmarshal(patched, &req); err != nil {
+			log.Error("failed to decode patched gpu", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		updated := req.ToGPU(id)
+
+		err = gpuPatcher.UpdateGPU(r.Context(), updated)
+		if errors.Is(err, storage.ErrGPUNotFound) {
+			log.Info("gpu not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("gpu not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrGPUAlreadyExists) {
+			log.Info("gpu already exists", slog.Int64("id", id))
+
+			render.Render(w, r, resp.AlreadyExists("gpu already exists"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update gpu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to update gpu"))
+
+			return
+		}
+
+		log.Info("gpu patched", slog.Int64("id", id))
+
+		responseOK(w, r, &updated)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *gpu.GPU) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		GPU:      res,
+	})
+}
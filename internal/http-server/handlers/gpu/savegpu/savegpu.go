@@ -1,6 +1,7 @@
 package savegpu
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -8,63 +9,131 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
 	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
 	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Response struct {
 	resp.Response
+	ID int64 `json:"id,omitempty"`
 }
 
 type RequestGPU struct {
-	Name         string `json:"name" validate:"required"`
-	Manufacturer string `json:"manufacturer" validate:"required"`
-	Memory       int64  `json:"memory" validate:"required"`
-	Frequency    int64  `json:"frequency" validate:"required"`
+	Name         string         `json:"name" validate:"required"`
+	ChipVendor   string         `json:"chip_vendor" validate:"omitempty,gpu_vendor"`
+	Manufacturer string         `json:"manufacturer" validate:"required"`
+	Memory       units.Bytes    `json:"memory" validate:"required" unit:"GiB"`
+	MemoryType   string         `json:"memory_type" validate:"omitempty,gpu_memory_type"`
+	BusWidth     int64          `json:"bus_width" validate:"min=0"`
+	Frequency    units.Hertz    `json:"frequency" validate:"required" unit:"MHz"`
+	BoardPower   int64          `json:"board_power" validate:"min=0"`
+	PCIeGen      int64          `json:"pcie_gen" validate:"omitempty,min=1,max=5"`
+	PCIeLanes    int64          `json:"pcie_lanes" validate:"omitempty,oneof=1 2 4 8 16"`
+	Length       int64          `json:"length" validate:"min=0"`
+	Outputs      RequestOutputs `json:"outputs"`
+}
+
+type RequestOutputs struct {
+	HDMI        int64 `json:"hdmi" validate:"min=0"`
+	DisplayPort int64 `json:"displayport" validate:"min=0"`
+	DVI         int64 `json:"dvi" validate:"min=0"`
+	USBC        int64 `json:"usb_c" validate:"min=0"`
+}
+
+// ToGPU converts the request into a gpu with the given id.
+func (req RequestGPU) ToGPU(id int64) gpu.GPU {
+	return gpu.GPU{
+		ID:           id,
+		Name:         req.Name,
+		ChipVendor:   req.ChipVendor,
+		Manufacturer: req.Manufacturer,
+		Memory:       req.Memory,
+		MemoryType:   req.MemoryType,
+		BusWidth:     req.BusWidth,
+		Frequency:    req.Frequency,
+		BoardPower:   req.BoardPower,
+		PCIeGen:      req.PCIeGen,
+		PCIeLanes:    req.PCIeLanes,
+		Length:       req.Length,
+		Outputs:      gpu.Outputs(req.Outputs),
+	}
+}
+
+// FromGPU is the inverse of ToGPU.
+func FromGPU(g gpu.GPU) RequestGPU {
+	return RequestGPU{
+		Name:         g.Name,
+		ChipVendor:   g.ChipVendor,
+		Manufacturer: g.Manufacturer,
+		Memory:       g.Memory,
+		MemoryType:   g.MemoryType,
+		BusWidth:     g.BusWidth,
+		Frequency:    g.Frequency,
+		BoardPower:   g.BoardPower,
+		PCIeGen:      g.PCIeGen,
+		PCIeLanes:    g.PCIeLanes,
+		Length:       g.Length,
+		Outputs:      RequestOutputs(g.Outputs),
+	}
 }
 
 type GPUSaver interface {
-	SaveGPU(name, manufacturer string, memory, frequency int64) (int64, error)
+	SaveGPU(ctx context.Context, g gpu.GPU) (int64, error)
 }
 
 func New(log *slog.Logger, gpuSaver GPUSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.savegpu.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		var req RequestGPU
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request body"))
+			render.Render(w, r, resp.DecodeError(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.Render(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		id, err := gpuSaver.SaveGPU(req.Name, req.Manufacturer, req.Memory, req.Frequency)
+		id, err := gpuSaver.SaveGPU(r.Context(), req.ToGPU(0))
 		if errors.Is(err, storage.ErrGPUAlreadyExists) {
 			log.Info("gpu already exists", slog.Int64("id", id))
 
-			render.JSON(w, r, resp.Error("gpu already exists"))
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("gpu already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
 
 			return
 		}
@@ -72,19 +141,20 @@ func New(log *slog.Logger, gpuSaver GPUSaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to save gpu", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to save gpu"))
+			render.Render(w, r, resp.StorageError("failed to save gpu"))
 
 			return
 		}
 
 		log.Info("gpu saved", slog.Int64("id", id))
 
-		responseOK(w, r)
+		responseOK(w, r, id)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request) {
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
+		ID:       id,
 	})
 }
@@ -0,0 +1,422 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/build/savebuild"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/cpu/savecpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/gpu/savegpu"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/savememory"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/deletepc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/getpc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/savepc"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/deleteram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/getram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/listram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/patchram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/saveram"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/updateram"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/handlers/slogdiscard"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage/memstore"
+)
+
+// newRouter routes the handlers as main does, on an empty memstore.
+func newRouter() http.Handler {
+	log := slogdiscard.NewDiscardLogger()
+	repo := memstore.New()
+
+	render.Respond = resp.Respond
+
+	router := chi.NewRouter()
+
+	router.Post("/save/pc", savepc.New(log, repo))
+	router.Post("/save/ram", saveram.New(log, repo))
+	router.Post("/save/cpu", savecpu.New(log, repo))
+	router.Post("/save/gpu", savegpu.New(log, repo))
+	router.Post("/save/memory", savememory.New(log, repo))
+	router.Post("/builds", savebuild.New(log, repo))
+
+	router.Get("/ram", listram.New(log, repo))
+	router.Get("/pc/{id}", getpc.New(log, repo))
+	router.Get("/ram/{id}", getram.New(log, repo))
+
+	router.Put("/ram/{id}", updateram.New(log, repo))
+	router.Patch("/ram/{id}", patchram.New(log, repo))
+
+	router.Delete("/pc/{id}", deletepc.New(log, repo))
+	router.Delete("/ram/{id}", deleteram.New(log, repo))
+
+	return router
+}
+
+// do sends the request and decodes the response body into out, if given.
+func do(t *testing.T, h http.Handler, method, path, body string, out any) int {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	h.ServeHTTP(rec, req)
+
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: decode %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+
+	return rec.Code
+}
+
+// save posts body to /save/<kind> and returns the id of the saved component.
+func save(t *testing.T, h http.Handler, kind, body string) int64 {
+	t.Helper()
+
+	var res struct {
+		ID int64 `json:"id"`
+	}
+	if code := do(t, h, http.MethodPost, "/save/"+kind, body, &res); code != http.StatusOK {
+		t.Fatalf("save %s: status %d", kind, code)
+	}
+
+	return res.ID
+}
+
+const vengeance = `{"name": "Vengeance", "memory_type": "DDR5", "capacity": "32GiB", "speed": 6000, "modules": 2}`
+
+func TestRAM(t *testing.T) {
+	router := newRouter()
+
+	var saved saveram.Response
+	if code := do(t, router, http.MethodPost, "/save/ram", vengeance, &saved); code != http.StatusOK {
+		t.Fatalf("save: status %d, %+v", code, saved)
+	}
+	if saved.Status != resp.StatusOk || saved.ID == 0 {
+		t.Fatalf("save: %+v", saved)
+	}
+
+	var dup saveram.Response
+	if code := do(t, router, http.MethodPost, "/save/ram", vengeance, &dup); code != http.StatusConflict {
+		t.Errorf("save duplicate: status %d, want %d", code, http.StatusConflict)
+	}
+	if dup.ID != saved.ID {
+		t.Errorf("save duplicate: id %d, want the existing %d", dup.ID, saved.ID)
+	}
+
+	path := fmt.Sprintf("/ram/%d", saved.ID)
+
+	var got getram.Response
+	if code := do(t, router, http.MethodGet, path, "", &got); code != http.StatusOK {
+		t.Fatalf("get: status %d", code)
+	}
+	want := ram.RAM{
+		ID: saved.ID, Name: "Vengeance", MemoryType: ram.DDR5,
+		Capacity: 32 * units.GiB, Speed: 6000, Modules: 2,
+	}
+	if got.RAM == nil || *got.RAM != want {
+		t.Errorf("get: %+v, want %+v", got.RAM, want)
+	}
+
+	if code := do(t, router, http.MethodDelete, path, "", nil); code != http.StatusOK {
+		t.Fatalf("delete: status %d", code)
+	}
+	if code := do(t, router, http.MethodGet, path, "", nil); code != http.StatusNotFound {
+		t.Errorf("get deleted: status %d, want %d", code, http.StatusNotFound)
+	}
+	if code := do(t, router, http.MethodDelete, path, "", nil); code != http.StatusNotFound {
+		t.Errorf("delete deleted: status %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestRAMLegacyCapacity(t *testing.T) {
+	router := newRouter()
+
+	id := save(t, router, "ram", `{"name": "Fury", "memory_type": "DDR4", "capacity": 16}`)
+
+	var got getram.Response
+	if code := do(t, router, http.MethodGet, fmt.Sprintf("/ram/%d", id), "", &got); code != http.StatusOK {
+		t.Fatalf("get: status %d", code)
+	}
+	if got.RAM.Capacity != 16*units.GiB {
+		t.Errorf("capacity %v, want 16 GiB", got.RAM.Capacity)
+	}
+
+	var dup saveram.Response
+	if code := do(t, router, http.MethodPost, "/save/ram", `{"name": "Fury", "memory_type": "DDR4", "capacity": "16GiB"}`, &dup); code != http.StatusConflict || dup.ID != id {
+		t.Errorf("save with unit: status %d, id %d, want %d for the same kit", code, dup.ID, id)
+	}
+}
+
+func TestRAMErrors(t *testing.T) {
+	router := newRouter()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"invalid json", http.MethodPost, "/save/ram", `{"name":`, http.StatusBadRequest},
+		{"missing name", http.MethodPost, "/save/ram", `{"memory_type": "DDR5", "capacity": "16GB"}`, http.StatusUnprocessableEntity},
+		{"unknown memory type", http.MethodPost, "/save/ram", `{"name": "Fury", "memory_type": "DDR9", "capacity": "16GB"}`, http.StatusUnprocessableEntity},
+		{"capacity in unknown unit", http.MethodPost, "/save/ram", `{"name": "Fury", "memory_type": "DDR5", "capacity": "16 bits"}`, http.StatusUnprocessableEntity},
+		{"get invalid id", http.MethodGet, "/ram/first", "", http.StatusBadRequest},
+		{"get missing", http.MethodGet, "/ram/42", "", http.StatusNotFound},
+		{"delete invalid id", http.MethodDelete, "/ram/first", "", http.StatusBadRequest},
+		{"delete missing", http.MethodDelete, "/ram/42", "", http.StatusNotFound},
+		{"list invalid filter", http.MethodGet, "/ram?capacity=16XB", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res resp.Response
+			if code := do(t, router, tt.method, tt.path, tt.body, &res); code != tt.want {
+				t.Errorf("status %d, want %d", code, tt.want)
+			}
+			if res.Status != resp.StatusError || res.Error == "" {
+				t.Errorf("response %+v, want an error", res)
+			}
+		})
+	}
+}
+
+func TestUpdateRAM(t *testing.T) {
+	router := newRouter()
+
+	id := save(t, router, "ram", vengeance)
+	other := save(t, router, "ram", `{"name": "Fury", "memory_type": "DDR4", "capacity": "16GiB"}`)
+	path := fmt.Sprintf("/ram/%d", id)
+
+	get := func() ram.RAM {
+		t.Helper()
+
+		var got getram.Response
+		if code := do(t, router, http.MethodGet, path, "", &got); code != http.StatusOK || got.RAM == nil {
+			t.Fatalf("get: status %d", code)
+		}
+
+		return *got.RAM
+	}
+
+	body := `{"name": "Vengeance RGB", "memory_type": "DDR5", "capacity": "32GiB", "speed": 6400}`
+	if code := do(t, router, http.MethodPut, path, body, nil); code != http.StatusOK {
+		t.Fatalf("put: status %d", code)
+	}
+	want := ram.RAM{ID: id, Name: "Vengeance RGB", MemoryType: ram.DDR5, Capacity: 32 * units.GiB, Speed: 6400, Modules: 1}
+	if got := get(); got != want {
+		t.Errorf("after put: %+v, want %+v, omitted fields reset", got, want)
+	}
+
+	if code := do(t, router, http.MethodPatch, path, `{"cas_latency": 32, "modules": 2}`, nil); code != http.StatusOK {
+		t.Fatalf("patch: status %d", code)
+	}
+	want.CASLatency, want.Modules = 32, 2
+	if got := get(); got != want {
+		t.Errorf("after patch: %+v, want %+v, other fields kept", got, want)
+	}
+
+	if code := do(t, router, http.MethodPatch, path, `{"cas_latency": null}`, nil); code != http.StatusOK {
+		t.Fatalf("patch null: status %d", code)
+	}
+	want.CASLatency = 0
+	if got := get(); got != want {
+		t.Errorf("after patch null: %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"put missing", http.MethodPut, "/ram/42", body, http.StatusNotFound},
+		{"put invalid", http.MethodPut, path, `{"name": "Vengeance"}`, http.StatusUnprocessableEntity},
+		{"put duplicate", http.MethodPut, path, `{"name": "Fury", "memory_type": "DDR4", "capacity": "16GiB"}`, http.StatusConflict},
+		{"patch missing", http.MethodPatch, "/ram/42", `{"speed": 3200}`, http.StatusNotFound},
+		{"patch deletes required field", http.MethodPatch, path, `{"name": null}`, http.StatusUnprocessableEntity},
+		{"patch invalid value", http.MethodPatch, path, `{"memory_type": "DDR9"}`, http.StatusUnprocessableEntity},
+		{"patch invalid size", http.MethodPatch, path, `{"capacity": "32 bits"}`, http.StatusUnprocessableEntity},
+		{"patch duplicate", http.MethodPatch, path, `{"name": "Fury", "memory_type": "DDR4", "capacity": "16GiB"}`, http.StatusConflict},
+		{"patch not an object", http.MethodPatch, path, `["Fury"]`, http.StatusBadRequest},
+		{"patch invalid json", http.MethodPatch, path, `{"speed":`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res resp.Response
+			if code := do(t, router, tt.method, tt.path, tt.body, &res); code != tt.want {
+				t.Errorf("status %d, want %d: %+v", code, tt.want, res)
+			}
+		})
+	}
+
+	// rejected updates leave the ram as it was
+	if got := get(); got != want {
+		t.Errorf("after rejected updates: %+v, want %+v", got, want)
+	}
+
+	var kept getram.Response
+	if code := do(t, router, http.MethodGet, fmt.Sprintf("/ram/%d", other), "", &kept); code != http.StatusOK || kept.RAM.Name != "Fury" {
+		t.Errorf("other ram: status %d, %+v", code, kept.RAM)
+	}
+}
+
+func TestListRAM(t *testing.T) {
+	router := newRouter()
+
+	var empty resp.Page[ram.RAM]
+	if code := do(t, router, http.MethodGet, "/ram", "", &empty); code != http.StatusOK {
+		t.Fatalf("list empty: status %d", code)
+	}
+	if empty.Items == nil || len(empty.Items) != 0 {
+		t.Errorf("list empty: %+v, want no items", empty.Items)
+	}
+
+	save(t, router, "ram", vengeance)
+	save(t, router, "ram", `{"name": "Fury", "memory_type": "DDR4", "capacity": "16GiB", "speed": 3200}`)
+	save(t, router, "ram", `{"name": "Ripjaws", "memory_type": "DDR5", "capacity": "64GiB", "speed": 6400}`)
+
+	var page resp.Page[ram.RAM]
+	if code := do(t, router, http.MethodGet, "/ram?limit=2", "", &page); code != http.StatusOK {
+		t.Fatalf("list: status %d", code)
+	}
+	if len(page.Items) != 2 || page.NextCursor == "" {
+		t.Fatalf("list first page: %+v", page)
+	}
+
+	var last resp.Page[ram.RAM]
+	if code := do(t, router, http.MethodGet, "/ram?limit=2&cursor="+page.NextCursor, "", &last); code != http.StatusOK {
+		t.Fatalf("list next: status %d", code)
+	}
+	if len(last.Items) != 1 || last.NextCursor != "" {
+		t.Errorf("list last page: %+v", last)
+	}
+
+	// numbers without a unit are in GiB as before sizes had units
+	var large resp.Page[ram.RAM]
+	if code := do(t, router, http.MethodGet, "/ram?capacity_gte=32", "", &large); code != http.StatusOK {
+		t.Fatalf("list legacy filter: status %d", code)
+	}
+	if len(large.Items) != 2 {
+		t.Errorf("list legacy filter: %+v, want the 32 and 64 GiB kits", large.Items)
+	}
+
+	var ddr5 resp.Page[ram.RAM]
+	if code := do(t, router, http.MethodGet, "/ram?memory_type=DDR5", "", &ddr5); code != http.StatusOK {
+		t.Fatalf("list filtered: status %d", code)
+	}
+	if len(ddr5.Items) != 2 {
+		t.Errorf("list filtered: %+v, want the two DDR5 kits", ddr5.Items)
+	}
+}
+
+func TestPC(t *testing.T) {
+	router := newRouter()
+
+	cpuID := save(t, router, "cpu", `{"name": "Ryzen 7 7700", "socket": "AM5", "cores": 8, "threads": 16, "base_clock": "3.8GHz"}`)
+	ramID := save(t, router, "ram", vengeance)
+	gpuID := save(t, router, "gpu", `{"name": "RTX 4070", "manufacturer": "ASUS", "memory": "12GiB", "frequency": "1.92GHz"}`)
+	memoryID := save(t, router, "memory", `{"name": "990 Pro", "capacity": "2TB", "storage_type": "NVMe"}`)
+
+	body := func(cpuID int64) string {
+		b, err := json.Marshal(savepc.RequestPC{
+			Name:   "Workstation",
+			CPUID:  cpuID,
+			RAM:    []savepc.RequestPart{{ID: ramID}},
+			GPU:    []savepc.RequestPart{{ID: gpuID}},
+			Memory: []savepc.RequestPart{{ID: memoryID}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(b)
+	}
+
+	var missing resp.Response
+	if code := do(t, router, http.MethodPost, "/save/pc", body(cpuID+100), &missing); code != http.StatusUnprocessableEntity {
+		t.Errorf("save with missing cpu: status %d, want %d", code, http.StatusUnprocessableEntity)
+	}
+	if want := fmt.Sprintf("cpu with id %d not found", cpuID+100); missing.Error != want {
+		t.Errorf("save with missing cpu: error %q, want %q", missing.Error, want)
+	}
+
+	pcID := save(t, router, "pc", body(cpuID))
+
+	pcPath, ramPath := fmt.Sprintf("/pc/%d", pcID), fmt.Sprintf("/ram/%d", ramID)
+
+	var got getpc.Response
+	if code := do(t, router, http.MethodGet, pcPath, "", &got); code != http.StatusOK {
+		t.Fatalf("get: status %d", code)
+	}
+	if got.PC == nil || got.PC.ID != pcID || got.PC.Name != "Workstation" {
+		t.Errorf("get: %+v", got.PC)
+	}
+
+	if code := do(t, router, http.MethodDelete, ramPath, "", nil); code != http.StatusConflict {
+		t.Errorf("delete used ram: status %d, want %d", code, http.StatusConflict)
+	}
+
+	if code := do(t, router, http.MethodDelete, pcPath, "", nil); code != http.StatusOK {
+		t.Fatalf("delete pc: status %d", code)
+	}
+	if code := do(t, router, http.MethodDelete, ramPath, "", nil); code != http.StatusOK {
+		t.Errorf("delete ram after pc: status %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	router := newRouter()
+
+	cpuID := save(t, router, "cpu", `{"name": "Ryzen 7 7700", "socket": "AM5", "cores": 8, "threads": 16, "base_clock": "3.8GHz"}`)
+	ramID := save(t, router, "ram", vengeance)
+
+	// the ram matches the stored one, the gpu and memory are new
+	body := fmt.Sprintf(`{
+		"name": "Workstation",
+		"cpu_id": %d,
+		"ram": [%s],
+		"gpu": [{"name": "RTX 4070", "manufacturer": "ASUS", "memory": "12GiB", "frequency": "1.92GHz"}],
+		"memory": [{"name": "990 Pro", "capacity": "2TB", "storage_type": "NVMe"}]
+	}`, cpuID, vengeance)
+
+	var saved savebuild.Response
+	if code := do(t, router, http.MethodPost, "/builds", body, &saved); code != http.StatusOK {
+		t.Fatalf("save: status %d, %+v", code, saved.Response)
+	}
+	if saved.PC == nil || saved.PC.CPUID != cpuID || len(saved.PC.RAM) != 1 || saved.PC.RAM[0].ID != ramID {
+		t.Fatalf("save: %+v, want ram %d reused", saved.PC, ramID)
+	}
+	if len(saved.PC.GPU) != 1 || saved.PC.GPU[0].ID == 0 {
+		t.Errorf("save: gpu %+v, want a new gpu", saved.PC.GPU)
+	}
+
+	var dup resp.Response
+	if code := do(t, router, http.MethodPost, "/builds", body, &dup); code != http.StatusConflict {
+		t.Errorf("save duplicate name: status %d, want %d", code, http.StatusConflict)
+	}
+	if dup.Error != "pc already exists" {
+		t.Errorf("save duplicate name: error %q", dup.Error)
+	}
+
+	renamed := strings.Replace(body, "Workstation", "Workstation 2", 1)
+	var again savebuild.Response
+	if code := do(t, router, http.MethodPost, "/builds", renamed, &again); code != http.StatusOK {
+		t.Fatalf("save again: status %d, %+v", code, again.Response)
+	}
+	if again.PC == nil || again.PC.GPU[0].ID != saved.PC.GPU[0].ID || again.PC.Memory[0].ID != saved.PC.Memory[0].ID {
+		t.Errorf("save again: %+v, want the components of %+v", again.PC, saved.PC)
+	}
+}
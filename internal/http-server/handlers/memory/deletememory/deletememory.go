@@ -0,0 +1,77 @@
+package deletememory
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type MemoryDeleter interface {
+	DeleteMemory(ctx context.Context, id int64) error
+}
+
+func New(log *slog.Logger, memoryDeleter MemoryDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.deletememory.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		err = memoryDeleter.DeleteMemory(r.Context(), id)
+		if errors.Is(err, storage.ErrMemoryNotFound) {
+			log.Info("memory not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("memory not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrMemoryInUse) {
+			log.Info("memory is used by a pc", slog.Int64("id", id))
+
+			render.Render(w, r, resp.Conflict("memory is used by a pc"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to delete memory", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to delete memory"))
+
+			return
+		}
+
+		log.Info("memory deleted", slog.Int64("id", id))
+
+		render.JSON(w, r, resp.OK())
+	}
+}
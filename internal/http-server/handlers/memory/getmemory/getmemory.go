@@ -0,0 +1,82 @@
+package getmemory
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Memory *memory.Memory `json:"memory,omitempty"`
+}
+
+type MemoryGetter interface {
+	GetMemory(ctx context.Context, id int64) (*memory.Memory, error)
+}
+
+func New(log *slog.Logger, memoryGetter MemoryGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.getmemory.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		res, err := memoryGetter.GetMemory(r.Context(), id)
+		if errors.Is(err, storage.ErrMemoryNotFound) {
+			log.Info("memory not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("memory not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get memory", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get memory"))
+
+			return
+		}
+
+		log.Info("memory found", slog.Int64("id", id))
+
+		responseOK(w, r, res)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *memory.Memory) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		Memory:   res,
+	})
+}
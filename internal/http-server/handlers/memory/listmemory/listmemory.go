@@ -0,0 +1,108 @@
+package listmemory
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/listquery"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type MemoryLister interface {
+	ListMemory(ctx context.Context, filter storage.MemoryFilter, opts storage.ListOptions) ([]memory.Memory, string, error)
+}
+
+func New(log *slog.Logger, memoryLister MemoryLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.listmemory.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		q := r.URL.Query()
+
+		opts, err := listquery.Options(q)
+		if err != nil {
+			log.Error("invalid list options", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		filter, err := parseFilter(q)
+		if err != nil {
+			log.Error("invalid filter", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		items, next, err := memoryLister.ListMemory(r.Context(), filter, opts)
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid cursor"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidSort) {
+			log.Info("invalid sort field", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid sort field"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to list memory", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to list memory"))
+
+			return
+		}
+
+		log.Info("memory listed", slog.Int("count", len(items)))
+
+		render.JSON(w, r, resp.NewPage(items, next))
+	}
+}
+
+func parseFilter(q url.Values) (storage.MemoryFilter, error) {
+	filter := storage.MemoryFilter{
+		Name:        q.Get("name"),
+		StorageType: q.Get("storage_type"),
+		Interface:   q.Get("interface"),
+		FormFactor:  q.Get("form_factor"),
+	}
+	var err error
+
+	if filter.Capacity, err = listquery.Bytes(q, "capacity", "GB"); err != nil {
+		return filter, err
+	}
+	if filter.ReadSpeed, err = listquery.Int64(q, "read_speed"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,174 @@
+package patchmemory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/memory/savememory"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/mergepatch"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Memory *memory.Memory `json:"memory,omitempty"`
+}
+
+type MemoryPatcher interface {
+	GetMemory(ctx context.Context, id int64) (*memory.Memory, error)
+	UpdateMemory(ctx context.Context, m memory.Memory) error
+}
+
+func New(log *slog.Logger, memoryPatcher MemoryPatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.patchmemory.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("failed to read request body"))
+
+			return
+		}
+
+		current, err := memoryPatcher.GetMemory(r.Context(), id)
+		if errors.Is(err, storage.ErrMemoryNotFound) {
+			log.Info("memory not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("memory not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get memory", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get memory"))
+
+			return
+		}
+
+		original, err := json.Marshal(savememory.FromMemory(*current))
+		if err != nil {
+			log.Error("failed to encode memory", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to patch memory"))
+
+			return
+		}
+
+		patched, err := mergepatch.Apply(original, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		var req savememory.RequestMemory
+
+		if err := request.Unmarshal(patched, &req); err != nil {
+			log.Error("failed to decode patched memory", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		updated := req.ToMemory(id)
+
+		err = memoryPatcher.UpdateMemory(r.Context(), updated)
+		if errors.Is(err, storage.ErrMemoryNotFound) {
+			log.Info("memory not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("memory not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrMemoryAlreadyExists) {
+			log.Info("memory already exists", slog.Int64("id", id))
+
+			render.Render(w, r, resp.AlreadyExists("memory already exists"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update memory", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to update memory"))
+
+			return
+		}
+
+		log.Info("memory patched", slog.Int64("id", id))
+
+		responseOK(w, r, &updated)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *memory.Memory) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		Memory:   res,
+	})
+}
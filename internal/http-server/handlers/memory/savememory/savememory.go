@@ -1,6 +1,7 @@
 package savememory
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -8,62 +9,112 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
 	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
 	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Response struct {
 	resp.Response
+	ID int64 `json:"id,omitempty"`
 }
 
 type RequestMemory struct {
-	Name        string `json:"name" validate:"required"`
-	Capacity    int64  `json:"capacity" validate:"required"`
-	StorageType string `json:"storage_type" validate:"required"`
+	Name        string      `json:"name" validate:"required"`
+	Capacity    units.Bytes `json:"capacity" validate:"required" unit:"GB"`
+	StorageType string      `json:"storage_type" validate:"required,storage_type"`
+	Interface   string      `json:"interface" validate:"omitempty,storage_interface"`
+	FormFactor  string      `json:"form_factor" validate:"omitempty,storage_form_factor"`
+	ReadSpeed   int64       `json:"read_speed" validate:"min=0"`
+	WriteSpeed  int64       `json:"write_speed" validate:"min=0"`
+	TBW         int64       `json:"tbw" validate:"min=0"`
+}
+
+// ToMemory converts the request into memory with the given id.
+func (req RequestMemory) ToMemory(id int64) memory.Memory {
+	return memory.Memory{
+		ID:          id,
+		Name:        req.Name,
+		Capacity:    req.Capacity,
+		StorageType: req.StorageType,
+		Interface:   req.Interface,
+		FormFactor:  req.FormFactor,
+		ReadSpeed:   req.ReadSpeed,
+		WriteSpeed:  req.WriteSpeed,
+		TBW:         req.TBW,
+	}
+}
+
+// FromMemory is the inverse of ToMemory.
+func FromMemory(m memory.Memory) RequestMemory {
+	return RequestMemory{
+		Name:        m.Name,
+		Capacity:    m.Capacity,
+		StorageType: m.StorageType,
+		Interface:   m.Interface,
+		FormFactor:  m.FormFactor,
+		ReadSpeed:   m.ReadSpeed,
+		WriteSpeed:  m.WriteSpeed,
+		TBW:         m.TBW,
+	}
 }
 
 type MemorySaver interface {
-	SaveMemory(name string, capacity int64, storage_type string) (int64, error)
+	SaveMemory(ctx context.Context, m memory.Memory) (int64, error)
 }
 
 func New(log *slog.Logger, memorySaver MemorySaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.savememory.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		var req RequestMemory
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request body"))
+			render.Render(w, r, resp.DecodeError(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.Render(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		id, err := memorySaver.SaveMemory(req.Name, req.Capacity, req.StorageType)
+		id, err := memorySaver.SaveMemory(r.Context(), req.ToMemory(0))
 		if errors.Is(err, storage.ErrMemoryAlreadyExists) {
 			log.Info("memory already exists", slog.Int64("id", id))
 
-			render.JSON(w, r, resp.Error("memory already exists"))
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("memory already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
 
 			return
 		}
@@ -71,19 +122,20 @@ func New(log *slog.Logger, memorySaver MemorySaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to save memory", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to save memory"))
+			render.Render(w, r, resp.StorageError("failed to save memory"))
 
 			return
 		}
 
 		log.Info("memory saved", slog.Int64("id", id))
 
-		responseOK(w, r)
+		responseOK(w, r, id)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request) {
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
+		ID:       id,
 	})
 }
@@ -0,0 +1,82 @@
+package getmotherboard
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Motherboard *motherboard.Motherboard `json:"motherboard,omitempty"`
+}
+
+type MotherboardGetter interface {
+	GetMotherboard(ctx context.Context, id int64) (*motherboard.Motherboard, error)
+}
+
+func New(log *slog.Logger, motherboardGetter MotherboardGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.getmotherboard.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		res, err := motherboardGetter.GetMotherboard(r.Context(), id)
+		if errors.Is(err, storage.ErrMotherboardNotFound) {
+			log.Info("motherboard not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("motherboard not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get motherboard", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get motherboard"))
+
+			return
+		}
+
+		log.Info("motherboard found", slog.Int64("id", id))
+
+		responseOK(w, r, res)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *motherboard.Motherboard) {
+	render.JSON(w, r, Response{
+		Response:    resp.OK(),
+		Motherboard: res,
+	})
+}
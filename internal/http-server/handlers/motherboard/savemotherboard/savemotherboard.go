@@ -0,0 +1,129 @@
+package savemotherboard
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	ID int64 `json:"id,omitempty"`
+}
+
+type RequestMotherboard struct {
+	Name       string      `json:"name" validate:"required"`
+	Socket     string      `json:"socket" validate:"required"`
+	Chipset    string      `json:"chipset" validate:"required"`
+	FormFactor string      `json:"form_factor" validate:"required,oneof=E-ATX ATX Micro-ATX Mini-ITX"`
+	MemoryType string      `json:"memory_type" validate:"required,ram_type"`
+	RAMSlots   int64       `json:"ram_slots" validate:"required,min=1"`
+	MaxMemory  units.Bytes `json:"max_memory" validate:"min=0" unit:"GiB"`
+	M2Slots    int64       `json:"m2_slots" validate:"min=0"`
+	SATAPorts  int64       `json:"sata_ports" validate:"min=0"`
+}
+
+// ToMotherboard converts the request into a motherboard with the given id.
+func (req RequestMotherboard) ToMotherboard(id int64) motherboard.Motherboard {
+	return motherboard.Motherboard{
+		ID:         id,
+		Name:       req.Name,
+		Socket:     req.Socket,
+		Chipset:    req.Chipset,
+		FormFactor: req.FormFactor,
+		MemoryType: req.MemoryType,
+		RAMSlots:   req.RAMSlots,
+		MaxMemory:  req.MaxMemory,
+		M2Slots:    req.M2Slots,
+		SATAPorts:  req.SATAPorts,
+	}
+}
+
+type MotherboardSaver interface {
+	SaveMotherboard(ctx context.Context, m motherboard.Motherboard) (int64, error)
+}
+
+func New(log *slog.Logger, motherboardSaver MotherboardSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.savemotherboard.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req RequestMotherboard
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		id, err := motherboardSaver.SaveMotherboard(r.Context(), req.ToMotherboard(0))
+		if errors.Is(err, storage.ErrMotherboardAlreadyExists) {
+			log.Info("motherboard already exists", slog.Int64("id", id))
+
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("motherboard already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save motherboard", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to save motherboard"))
+
+			return
+		}
+
+		log.Info("motherboard saved", slog.Int64("id", id))
+
+		responseOK(w, r, id)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		ID:       id,
+	})
+}
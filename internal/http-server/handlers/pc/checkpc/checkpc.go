@@ -0,0 +1,85 @@
+package checkpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/services/compatibility"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Compatibility *compatibility.Report `json:"compatibility,omitempty"`
+}
+
+type PCGetter interface {
+	GetExpandedPC(ctx context.Context, id int64) (*pc.Expanded, error)
+}
+
+func New(log *slog.Logger, pcGetter PCGetter, checker *compatibility.Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.checkpc.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		p, err := pcGetter.GetExpandedPC(r.Context(), id)
+		if errors.Is(err, storage.ErrPCNotFound) {
+			log.Info("pc not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("pc not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get pc"))
+
+			return
+		}
+
+		report := checker.Check(p)
+
+		log.Info("pc checked",
+			slog.Int64("id", id),
+			slog.Int("errors", len(report.Errors)),
+			slog.Int("warnings", len(report.Warnings)),
+		)
+
+		render.JSON(w, r, Response{
+			Response:      resp.OK(),
+			Compatibility: &report,
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package getpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	PC *pc.PC `json:"pc,omitempty"`
+}
+
+// ExpandedResponse is returned for ?expand=all, with components resolved.
+type ExpandedResponse struct {
+	resp.Response
+	PC *pc.Expanded `json:"pc,omitempty"`
+}
+
+const expandAll = "all"
+
+type PCGetter interface {
+	GetPC(ctx context.Context, id int64) (*pc.PC, error)
+	GetExpandedPC(ctx context.Context, id int64) (*pc.Expanded, error)
+}
+
+func New(log *slog.Logger, pcGetter PCGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.getpc.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		expand := r.URL.Query().Get("expand")
+		if expand != "" && expand != expandAll {
+			log.Error("invalid expand", slog.String("expand", expand))
+
+			render.Render(w, r, resp.BadRequest("invalid expand, only \"all\" is supported"))
+
+			return
+		}
+
+		if expand == expandAll {
+			getExpanded(log, w, r, pcGetter, id)
+
+			return
+		}
+
+		res, err := pcGetter.GetPC(r.Context(), id)
+		if errors.Is(err, storage.ErrPCNotFound) {
+			log.Info("pc not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("pc not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get pc"))
+
+			return
+		}
+
+		log.Info("pc found", slog.Int64("id", id))
+
+		responseOK(w, r, res)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *pc.PC) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		PC:       res,
+	})
+}
+
+func getExpanded(log *slog.Logger, w http.ResponseWriter, r *http.Request, pcGetter PCGetter, id int64) {
+	res, err := pcGetter.GetExpandedPC(r.Context(), id)
+	if errors.Is(err, storage.ErrPCNotFound) {
+		log.Info("pc not found", slog.Int64("id", id))
+
+		render.Render(w, r, resp.NotFound("pc not found"))
+
+		return
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		log.Error("storage timed out", sl.Err(err))
+
+		render.Render(w, r, resp.Timeout())
+
+		return
+	}
+
+	if err != nil {
+		log.Error("failed to get expanded pc", sl.Err(err))
+
+		render.Render(w, r, resp.StorageError("failed to get pc"))
+
+		return
+	}
+
+	log.Info("expanded pc found", slog.Int64("id", id))
+
+	render.JSON(w, r, ExpandedResponse{
+		Response: resp.OK(),
+		PC:       res,
+	})
+}
@@ -0,0 +1,99 @@
+package getpower
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/services/power"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Power *power.Budget `json:"power,omitempty"`
+}
+
+type PCGetter interface {
+	GetExpandedPC(ctx context.Context, id int64) (*pc.Expanded, error)
+}
+
+// New estimates the draw of a pc. The headroom query parameter, a share
+// between 0 and 1, overrides the configured headroom.
+func New(log *slog.Logger, pcGetter PCGetter, headroom float64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.getpower.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		headroom := headroom
+		if raw := r.URL.Query().Get("headroom"); raw != "" {
+			headroom, err = strconv.ParseFloat(raw, 64)
+			if err != nil || headroom < 0 || headroom > 1 {
+				log.Error("invalid headroom", slog.String("headroom", raw))
+
+				render.Render(w, r, resp.BadRequest("invalid headroom, expected a number between 0 and 1"))
+
+				return
+			}
+		}
+
+		p, err := pcGetter.GetExpandedPC(r.Context(), id)
+		if errors.Is(err, storage.ErrPCNotFound) {
+			log.Info("pc not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("pc not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get pc"))
+
+			return
+		}
+
+		budget := power.Estimate(p, headroom)
+
+		log.Info("pc power estimated",
+			slog.Int64("id", id),
+			slog.Int64("total", budget.Total),
+			slog.Bool("under_provisioned", budget.UnderProvisioned),
+		)
+
+		render.JSON(w, r, Response{
+			Response: resp.OK(),
+			Power:    &budget,
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package listpc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/listquery"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type PCLister interface {
+	ListPC(ctx context.Context, filter storage.PCFilter, opts storage.ListOptions) ([]pc.PC, string, error)
+}
+
+func New(log *slog.Logger, pcLister PCLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.listpc.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		q := r.URL.Query()
+
+		opts, err := listquery.Options(q)
+		if err != nil {
+			log.Error("invalid list options", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		filter, err := parseFilter(q)
+		if err != nil {
+			log.Error("invalid filter", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		items, next, err := pcLister.ListPC(r.Context(), filter, opts)
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid cursor"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidSort) {
+			log.Info("invalid sort field", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid sort field"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to list pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to list pc"))
+
+			return
+		}
+
+		log.Info("pc listed", slog.Int("count", len(items)))
+
+		render.JSON(w, r, resp.NewPage(items, next))
+	}
+}
+
+func parseFilter(q url.Values) (storage.PCFilter, error) {
+	filter := storage.PCFilter{Name: q.Get("name")}
+	var err error
+
+	if filter.RAMID, err = listquery.OptionalInt64(q, "ram_id"); err != nil {
+		return filter, err
+	}
+	if filter.CPUID, err = listquery.OptionalInt64(q, "cpu_id"); err != nil {
+		return filter, err
+	}
+	if filter.GPUID, err = listquery.OptionalInt64(q, "gpu_id"); err != nil {
+		return filter, err
+	}
+	if filter.MemoryID, err = listquery.OptionalInt64(q, "memory_id"); err != nil {
+		return filter, err
+	}
+	if filter.MotherboardID, err = listquery.OptionalInt64(q, "motherboard_id"); err != nil {
+		return filter, err
+	}
+	if filter.PSUID, err = listquery.OptionalInt64(q, "psu_id"); err != nil {
+		return filter, err
+	}
+	if filter.CaseID, err = listquery.OptionalInt64(q, "case_id"); err != nil {
+		return filter, err
+	}
+	if filter.CoolerID, err = listquery.OptionalInt64(q, "cooler_id"); err != nil {
+		return filter, err
+	}
+	if filter.PeripheralID, err = listquery.OptionalInt64(q, "peripheral_id"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,182 @@
+package patchpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/pc/savepc"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/mergepatch"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	PC *pc.PC `json:"pc,omitempty"`
+}
+
+type PCPatcher interface {
+	GetPC(ctx context.Context, id int64) (*pc.PC, error)
+	UpdatePC(ctx context.Context, p pc.PC) error
+}
+
+func New(log *slog.Logger, pcPatcher PCPatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.patchpc.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("failed to read request body"))
+
+			return
+		}
+
+		current, err := pcPatcher.GetPC(r.Context(), id)
+		if errors.Is(err, storage.ErrPCNotFound) {
+			log.Info("pc not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("pc not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get pc"))
+
+			return
+		}
+
+		original, err := json.Marshal(savepc.FromPC(*current))
+		if err != nil {
+			log.Error("failed to encode pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to patch pc"))
+
+			return
+		}
+
+		patched, err := mergepatch.Apply(original, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		var req savepc.RequestPC
+
+		if err := request.Unmarshal(patched, &req); err != nil {
+			log.Error("failed to decode patched pc", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		updated := req.ToPC(id)
+
+		err = pcPatcher.UpdatePC(r.Context(), updated)
+		if errors.Is(err, storage.ErrPCNotFound) {
+			log.Info("pc not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("pc not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrComponentNotFound) {
+			log.Info("pc component not found", sl.Err(err))
+
+			render.Render(w, r, resp.ComponentNotFound(err))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrPCAlreadyExists) {
+			log.Info("pc already exists", slog.Int64("id", id))
+
+			render.Render(w, r, resp.AlreadyExists("pc already exists"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update pc", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to update pc"))
+
+			return
+		}
+
+		log.Info("pc patched", slog.Int64("id", id))
+
+		responseOK(w, r, &updated)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *pc.PC) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		PC:       res,
+	})
+}
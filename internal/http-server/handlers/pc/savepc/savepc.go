@@ -1,6 +1,7 @@
 package savepc
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -8,64 +9,158 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
 	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
 	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Response struct {
 	resp.Response
+	ID int64 `json:"id,omitempty"`
 }
 
 type RequestPC struct {
-	Name     string `json:"name" validate:"required"`
-	RAMID    int64  `json:"ram_id" validate:"required"`
-	CPUID    int64  `json:"cpu_id" validate:"required"`
-	GPUID    int64  `json:"gpu_id" validate:"required"`
-	MemoryID int64  `json:"memory_id" validate:"required"`
+	Name   string        `json:"name" validate:"required"`
+	CPUID  int64         `json:"cpu_id" validate:"required"`
+	RAM    []RequestPart `json:"ram" validate:"required,min=1,dive"`
+	GPU    []RequestPart `json:"gpu" validate:"required,min=1,dive"`
+	Memory []RequestPart `json:"memory" validate:"required,min=1,dive"`
+
+	MotherboardID int64 `json:"motherboard_id"`
+	PSUID         int64 `json:"psu_id"`
+	CaseID        int64 `json:"case_id"`
+	CoolerID      int64 `json:"cooler_id"`
+
+	Peripherals []RequestPart `json:"peripherals,omitempty" validate:"dive"`
+}
+
+// RequestPart references an existing component, quantity defaults to 1.
+type RequestPart struct {
+	ID       int64  `json:"id" validate:"required"`
+	Quantity int64  `json:"quantity" validate:"omitempty,min=1"`
+	Slot     string `json:"slot"`
+}
+
+// ToPC converts the request into a pc with the given id.
+func (req RequestPC) ToPC(id int64) pc.PC {
+	return pc.PC{
+		ID:     id,
+		Name:   req.Name,
+		CPUID:  req.CPUID,
+		RAM:    toParts(req.RAM),
+		GPU:    toParts(req.GPU),
+		Memory: toParts(req.Memory),
+
+		MotherboardID: req.MotherboardID,
+		PSUID:         req.PSUID,
+		CaseID:        req.CaseID,
+		CoolerID:      req.CoolerID,
+
+		Peripherals: toParts(req.Peripherals),
+	}
+}
+
+// FromPC is the inverse of ToPC.
+func FromPC(p pc.PC) RequestPC {
+	return RequestPC{
+		Name:   p.Name,
+		CPUID:  p.CPUID,
+		RAM:    fromParts(p.RAM),
+		GPU:    fromParts(p.GPU),
+		Memory: fromParts(p.Memory),
+
+		MotherboardID: p.MotherboardID,
+		PSUID:         p.PSUID,
+		CaseID:        p.CaseID,
+		CoolerID:      p.CoolerID,
+
+		Peripherals: fromParts(p.Peripherals),
+	}
+}
+
+func toParts(req []RequestPart) []pc.Part {
+	parts := make([]pc.Part, 0, len(req))
+	for _, r := range req {
+		quantity := r.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		parts = append(parts, pc.Part{ID: r.ID, Quantity: quantity, Slot: r.Slot})
+	}
+	return parts
+}
+
+func fromParts(parts []pc.Part) []RequestPart {
+	req := make([]RequestPart, 0, len(parts))
+	for _, p := range parts {
+		req = append(req, RequestPart{ID: p.ID, Quantity: p.Quantity, Slot: p.Slot})
+	}
+	return req
 }
 
 type PCSaver interface {
-	SavePC(name string, ramID, cpuID, gpuID, memoryID int64) (int64, error)
+	SavePC(ctx context.Context, p pc.PC) (int64, error)
 }
 
 func New(log *slog.Logger, pcSaver PCSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.savepc.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		var req RequestPC
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request body"))
+			render.Render(w, r, resp.DecodeError(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.Render(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		id, err := pcSaver.SavePC(req.Name, req.RAMID, req.CPUID, req.GPUID, req.MemoryID)
+		id, err := pcSaver.SavePC(r.Context(), req.ToPC(0))
 		if errors.Is(err, storage.ErrPCAlreadyExists) {
 			log.Info("pc already exists", slog.Int64("id", id))
 
-			render.JSON(w, r, resp.Error("pc already exists"))
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("pc already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrComponentNotFound) {
+			log.Info("pc component not found", sl.Err(err))
+
+			render.Render(w, r, resp.ComponentNotFound(err))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
 
 			return
 		}
@@ -73,19 +168,20 @@ func New(log *slog.Logger, pcSaver PCSaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to save pc", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to save pc"))
+			render.Render(w, r, resp.StorageError("failed to save pc"))
 
 			return
 		}
 
 		log.Info("pc saved", slog.Int64("id", id))
 
-		responseOK(w, r)
+		responseOK(w, r, id)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request) {
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
+		ID:       id,
 	})
 }
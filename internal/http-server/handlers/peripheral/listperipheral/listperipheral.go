@@ -0,0 +1,89 @@
+package listperipheral
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/listquery"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type PeripheralLister interface {
+	ListPeripheral(ctx context.Context, filter storage.PeripheralFilter, opts storage.ListOptions) ([]peripheral.Peripheral, string, error)
+}
+
+func New(log *slog.Logger, peripheralLister PeripheralLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.listperipheral.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		q := r.URL.Query()
+
+		opts, err := listquery.Options(q)
+		if err != nil {
+			log.Error("invalid list options", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		items, next, err := peripheralLister.ListPeripheral(r.Context(), parseFilter(q), opts)
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid cursor"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidSort) {
+			log.Info("invalid sort field", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid sort field"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to list peripheral", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to list peripheral"))
+
+			return
+		}
+
+		log.Info("peripheral listed", slog.Int("count", len(items)))
+
+		render.JSON(w, r, resp.NewPage(items, next))
+	}
+}
+
+func parseFilter(q url.Values) storage.PeripheralFilter {
+	return storage.PeripheralFilter{
+		Name:         q.Get("name"),
+		Type:         q.Get("type"),
+		Manufacturer: q.Get("manufacturer"),
+		Connection:   q.Get("connection"),
+	}
+}
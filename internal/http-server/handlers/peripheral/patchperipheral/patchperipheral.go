@@ -0,0 +1,174 @@
+package patchperipheral
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/peripheral/saveperipheral"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/mergepatch"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	Peripheral *peripheral.Peripheral `json:"peripheral,omitempty"`
+}
+
+type PeripheralPatcher interface {
+	GetPeripheral(ctx context.Context, id int64) (*peripheral.Peripheral, error)
+	UpdatePeripheral(ctx context.Context, p peripheral.Peripheral) error
+}
+
+func New(log *slog.Logger, peripheralPatcher PeripheralPatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.patchperipheral.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("failed to read request body"))
+
+			return
+		}
+
+		current, err := peripheralPatcher.GetPeripheral(r.Context(), id)
+		if errors.Is(err, storage.ErrPeripheralNotFound) {
+			log.Info("peripheral not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("peripheral not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get peripheral", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get peripheral"))
+
+			return
+		}
+
+		original, err := json.Marshal(saveperipheral.FromPeripheral(*current))
+		if err != nil {
+			log.Error("failed to encode peripheral", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to patch peripheral"))
+
+			return
+		}
+
+		patched, err := mergepatch.Apply(original, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		var req saveperipheral.RequestPeripheral
+
+		if err := request.Unmarshal(patched, &req); err != nil {
+			log.Error("failed to decode patched peripheral", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		updated := req.ToPeripheral(id)
+
+		err = peripheralPatcher.UpdatePeripheral(r.Context(), updated)
+		if errors.Is(err, storage.ErrPeripheralNotFound) {
+			log.Info("peripheral not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("peripheral not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrPeripheralAlreadyExists) {
+			log.Info("peripheral already exists", slog.Int64("id", id))
+
+			render.Render(w, r, resp.AlreadyExists("peripheral already exists"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update peripheral", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to update peripheral"))
+
+			return
+		}
+
+		log.Info("peripheral patched", slog.Int64("id", id))
+
+		responseOK(w, r, &updated)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *peripheral.Peripheral) {
+	render.JSON(w, r, Response{
+		Response:   resp.OK(),
+		Peripheral: res,
+	})
+}
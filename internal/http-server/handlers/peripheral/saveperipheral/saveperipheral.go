@@ -0,0 +1,160 @@
+package saveperipheral
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	ID int64 `json:"id,omitempty"`
+}
+
+// RequestPeripheral carries the details of its type, details given for
+// other types are dropped.
+type RequestPeripheral struct {
+	Name         string `json:"name" validate:"required"`
+	Type         string `json:"type" validate:"required,peripheral_type"`
+	Manufacturer string `json:"manufacturer"`
+	Connection   string `json:"connection" validate:"omitempty,peripheral_connection"`
+
+	Monitor  *RequestMonitor  `json:"monitor,omitempty"`
+	Keyboard *RequestKeyboard `json:"keyboard,omitempty"`
+	Network  *RequestNetwork  `json:"network,omitempty"`
+}
+
+type RequestMonitor struct {
+	Diagonal    float64 `json:"diagonal" validate:"min=0"`
+	Width       int64   `json:"width" validate:"min=0"`
+	Height      int64   `json:"height" validate:"min=0"`
+	RefreshRate int64   `json:"refresh_rate" validate:"min=0"`
+	Panel       string  `json:"panel" validate:"omitempty,oneof=IPS VA TN OLED"`
+}
+
+type RequestKeyboard struct {
+	Layout   string `json:"layout" validate:"omitempty,oneof=ANSI ISO JIS"`
+	Switches string `json:"switches"`
+}
+
+type RequestNetwork struct {
+	Speed     int64  `json:"speed" validate:"min=0"`
+	Ports     int64  `json:"ports" validate:"min=0"`
+	Standard  string `json:"standard"`
+	Bluetooth bool   `json:"bluetooth"`
+}
+
+// ToPeripheral converts the request into a peripheral with the given id.
+func (req RequestPeripheral) ToPeripheral(id int64) peripheral.Peripheral {
+	return peripheral.Peripheral{
+		ID:           id,
+		Name:         req.Name,
+		Type:         req.Type,
+		Manufacturer: req.Manufacturer,
+		Connection:   req.Connection,
+		Monitor:      (*peripheral.MonitorDetails)(req.Monitor),
+		Keyboard:     (*peripheral.KeyboardDetails)(req.Keyboard),
+		Network:      (*peripheral.NetworkDetails)(req.Network),
+	}.WithOwnDetails()
+}
+
+// FromPeripheral is the inverse of ToPeripheral.
+func FromPeripheral(p peripheral.Peripheral) RequestPeripheral {
+	return RequestPeripheral{
+		Name:         p.Name,
+		Type:         p.Type,
+		Manufacturer: p.Manufacturer,
+		Connection:   p.Connection,
+		Monitor:      (*RequestMonitor)(p.Monitor),
+		Keyboard:     (*RequestKeyboard)(p.Keyboard),
+		Network:      (*RequestNetwork)(p.Network),
+	}
+}
+
+type PeripheralSaver interface {
+	SavePeripheral(ctx context.Context, p peripheral.Peripheral) (int64, error)
+}
+
+func New(log *slog.Logger, peripheralSaver PeripheralSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.saveperipheral.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req RequestPeripheral
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		id, err := peripheralSaver.SavePeripheral(r.Context(), req.ToPeripheral(0))
+		if errors.Is(err, storage.ErrPeripheralAlreadyExists) {
+			log.Info("peripheral already exists", slog.Int64("id", id))
+
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("peripheral already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save peripheral", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to save peripheral"))
+
+			return
+		}
+
+		log.Info("peripheral saved", slog.Int64("id", id))
+
+		responseOK(w, r, id)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		ID:       id,
+	})
+}
@@ -0,0 +1,118 @@
+package savepsu
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	ID int64 `json:"id,omitempty"`
+}
+
+type RequestPSU struct {
+	Name       string `json:"name" validate:"required"`
+	Wattage    int64  `json:"wattage" validate:"required,min=1"`
+	Efficiency string `json:"efficiency" validate:"required,oneof='80 PLUS' '80 PLUS Bronze' '80 PLUS Silver' '80 PLUS Gold' '80 PLUS Platinum' '80 PLUS Titanium'"`
+	Modular    bool   `json:"modular"`
+}
+
+// ToPSU converts the request into a psu with the given id.
+func (req RequestPSU) ToPSU(id int64) psu.PSU {
+	return psu.PSU{
+		ID:         id,
+		Name:       req.Name,
+		Wattage:    req.Wattage,
+		Efficiency: req.Efficiency,
+		Modular:    req.Modular,
+	}
+}
+
+type PSUSaver interface {
+	SavePSU(ctx context.Context, p psu.PSU) (int64, error)
+}
+
+func New(log *slog.Logger, psuSaver PSUSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.savepsu.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req RequestPSU
+
+		err := request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		id, err := psuSaver.SavePSU(r.Context(), req.ToPSU(0))
+		if errors.Is(err, storage.ErrPSUAlreadyExists) {
+			log.Info("psu already exists", slog.Int64("id", id))
+
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("psu already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save psu", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to save psu"))
+
+			return
+		}
+
+		log.Info("psu saved", slog.Int64("id", id))
+
+		responseOK(w, r, id)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		ID:       id,
+	})
+}
@@ -0,0 +1,106 @@
+package listram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/listquery"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type RAMLister interface {
+	ListRAM(ctx context.Context, filter storage.RAMFilter, opts storage.ListOptions) ([]ram.RAM, string, error)
+}
+
+func New(log *slog.Logger, ramLister RAMLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.listram.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		q := r.URL.Query()
+
+		opts, err := listquery.Options(q)
+		if err != nil {
+			log.Error("invalid list options", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		filter, err := parseFilter(q)
+		if err != nil {
+			log.Error("invalid filter", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest(err.Error()))
+
+			return
+		}
+
+		items, next, err := ramLister.ListRAM(r.Context(), filter, opts)
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Info("invalid cursor", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid cursor"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidSort) {
+			log.Info("invalid sort field", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid sort field"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to list ram", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to list ram"))
+
+			return
+		}
+
+		log.Info("ram listed", slog.Int("count", len(items)))
+
+		render.JSON(w, r, resp.NewPage(items, next))
+	}
+}
+
+func parseFilter(q url.Values) (storage.RAMFilter, error) {
+	filter := storage.RAMFilter{
+		Name:       q.Get("name"),
+		MemoryType: q.Get("memory_type"),
+	}
+	var err error
+
+	if filter.Capacity, err = listquery.Bytes(q, "capacity", "GiB"); err != nil {
+		return filter, err
+	}
+	if filter.Speed, err = listquery.Int64(q, "speed"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,174 @@
+package patchram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/saveram"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/mergepatch"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	RAM *ram.RAM `json:"ram,omitempty"`
+}
+
+type RAMPatcher interface {
+	GetRAM(ctx context.Context, id int64) (*ram.RAM, error)
+	UpdateRAM(ctx context.Context, r ram.RAM) error
+}
+
+func New(log *slog.Logger, ramPatcher RAMPatcher) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.patchram.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		patch, err := io.ReadAll(r.Body)
+		if err != nil {
+			log.Error("failed to read request body", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("failed to read request body"))
+
+			return
+		}
+
+		current, err := ramPatcher.GetRAM(r.Context(), id)
+		if errors.Is(err, storage.ErrRAMNotFound) {
+			log.Info("ram not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("ram not found"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to get ram", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to get ram"))
+
+			return
+		}
+
+		original, err := json.Marshal(saveram.FromRAM(*current))
+		if err != nil {
+			log.Error("failed to encode ram", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to patch ram"))
+
+			return
+		}
+
+		patched, err := mergepatch.Apply(original, patch)
+		if err != nil {
+			log.Error("failed to apply patch", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		var req saveram.RequestRAM
+
+		if err := request.Unmarshal(patched, &req); err != nil {
+			log.Error("failed to decode patched ram", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		updated := req.ToRAM(id)
+
+		err = ramPatcher.UpdateRAM(r.Context(), updated)
+		if errors.Is(err, storage.ErrRAMNotFound) {
+			log.Info("ram not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("ram not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrRAMAlreadyExists) {
+			log.Info("ram already exists", slog.Int64("id", id))
+
+			render.Render(w, r, resp.AlreadyExists("ram already exists"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update ram", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to update ram"))
+
+			return
+		}
+
+		log.Info("ram patched", slog.Int64("id", id))
+
+		responseOK(w, r, &updated)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *ram.RAM) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		RAM:      res,
+	})
+}
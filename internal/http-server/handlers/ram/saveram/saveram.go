@@ -1,6 +1,7 @@
 package saveram
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -8,62 +9,110 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
 	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
 	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
 	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Response struct {
 	resp.Response
+	ID int64 `json:"id,omitempty"`
 }
 
 type RequestRAM struct {
-	Name        string `json:"name" validate:"required"`
-	Memory_type string `json:"memory_type" validate:"required"`
-	Capacity    int64  `json:"capacity" validate:"required"`
+	Name       string      `json:"name" validate:"required"`
+	MemoryType string      `json:"memory_type" validate:"required,ram_type"`
+	Capacity   units.Bytes `json:"capacity" validate:"required" unit:"GiB"`
+	Speed      int64       `json:"speed" validate:"min=0"`
+	CASLatency int64       `json:"cas_latency" validate:"min=0"`
+	Modules    int64       `json:"modules" validate:"omitempty,min=1"`
+	ECC        bool        `json:"ecc"`
+}
+
+// ToRAM converts the request into ram with the given id. A kit is one
+// module unless told otherwise, ECC memory types imply ECC.
+func (req RequestRAM) ToRAM(id int64) ram.RAM {
+	return ram.RAM{
+		ID:         id,
+		Name:       req.Name,
+		MemoryType: req.MemoryType,
+		Capacity:   req.Capacity,
+		Speed:      req.Speed,
+		CASLatency: req.CASLatency,
+		Modules:    max(req.Modules, 1),
+		ECC:        req.ECC || ram.Generation(req.MemoryType) != req.MemoryType,
+	}
+}
+
+// FromRAM is the inverse of ToRAM.
+func FromRAM(r ram.RAM) RequestRAM {
+	return RequestRAM{
+		Name:       r.Name,
+		MemoryType: r.MemoryType,
+		Capacity:   r.Capacity,
+		Speed:      r.Speed,
+		CASLatency: r.CASLatency,
+		Modules:    r.Modules,
+		ECC:        r.ECC,
+	}
 }
 
 type RAMSaver interface {
-	SaveRAM(name, memory_type string, capacity int64) (int64, error)
+	SaveRAM(ctx context.Context, r ram.RAM) (int64, error)
 }
 
 func New(log *slog.Logger, ramSaver RAMSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.saveram.New"
 
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
 		var req RequestRAM
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.DecodeJSON(r.Body, &req)
 		if err != nil {
 			log.Error("failed to decode request body", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request body"))
+			render.Render(w, r, resp.DecodeError(err))
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if err := validator.New().Struct(req); err != nil {
+		if err := validate.Struct(req); err != nil {
 			validateErr := err.(validator.ValidationErrors)
 
 			log.Error("invalid request", sl.Err(err))
 
-			render.JSON(w, r, resp.ValidationError(validateErr))
+			render.Render(w, r, resp.ValidationError(validateErr))
 
 			return
 		}
 
-		id, err := ramSaver.SaveRAM(req.Name, req.Memory_type, req.Capacity)
+		id, err := ramSaver.SaveRAM(r.Context(), req.ToRAM(0))
 		if errors.Is(err, storage.ErrRAMAlreadyExists) {
 			log.Info("ram already exists", slog.Int64("id", id))
 
-			render.JSON(w, r, resp.Error("ram already exists"))
+			render.Render(w, r, Response{
+				Response: resp.AlreadyExists("ram already exists"),
+				ID:       id,
+			})
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
 
 			return
 		}
@@ -71,19 +120,20 @@ func New(log *slog.Logger, ramSaver RAMSaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to save ram", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to save ram"))
+			render.Render(w, r, resp.StorageError("failed to save ram"))
 
 			return
 		}
 
 		log.Info("ram saved", slog.Int64("id", id))
 
-		responseOK(w, r)
+		responseOK(w, r, id)
 	}
 }
 
-func responseOK(w http.ResponseWriter, r *http.Request) {
+func responseOK(w http.ResponseWriter, r *http.Request, id int64) {
 	render.JSON(w, r, Response{
 		Response: resp.OK(),
+		ID:       id,
 	})
 }
@@ -0,0 +1,119 @@
+package updateram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/http-server/handlers/ram/saveram"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	resp "github.com/r33ta/pc-database-manager/internal/lib/api/response"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/validate"
+	"github.com/r33ta/pc-database-manager/internal/lib/logger/sl"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Response struct {
+	resp.Response
+	RAM *ram.RAM `json:"ram,omitempty"`
+}
+
+type RAMUpdater interface {
+	UpdateRAM(ctx context.Context, r ram.RAM) error
+}
+
+func New(log *slog.Logger, ramUpdater RAMUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.updateram.New"
+
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+		if err != nil {
+			log.Error("invalid id", sl.Err(err))
+
+			render.Render(w, r, resp.BadRequest("invalid id"))
+
+			return
+		}
+
+		var req saveram.RequestRAM
+
+		err = request.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+
+			render.Render(w, r, resp.DecodeError(err))
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if err := validate.Struct(req); err != nil {
+			validateErr := err.(validator.ValidationErrors)
+
+			log.Error("invalid request", sl.Err(err))
+
+			render.Render(w, r, resp.ValidationError(validateErr))
+
+			return
+		}
+
+		updated := req.ToRAM(id)
+
+		err = ramUpdater.UpdateRAM(r.Context(), updated)
+		if errors.Is(err, storage.ErrRAMNotFound) {
+			log.Info("ram not found", slog.Int64("id", id))
+
+			render.Render(w, r, resp.NotFound("ram not found"))
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrRAMAlreadyExists) {
+			log.Info("ram already exists", slog.Int64("id", id))
+
+			render.Render(w, r, resp.AlreadyExists("ram already exists"))
+
+			return
+		}
+
+		if errors.Is(err, context.DeadlineExceeded) {
+			log.Error("storage timed out", sl.Err(err))
+
+			render.Render(w, r, resp.Timeout())
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update ram", sl.Err(err))
+
+			render.Render(w, r, resp.StorageError("failed to update ram"))
+
+			return
+		}
+
+		log.Info("ram updated", slog.Int64("id", id))
+
+		responseOK(w, r, &updated)
+	}
+}
+
+func responseOK(w http.ResponseWriter, r *http.Request, res *ram.RAM) {
+	render.JSON(w, r, Response{
+		Response: resp.OK(),
+		RAM:      res,
+	})
+}
@@ -0,0 +1,101 @@
+// Package listquery parses pagination, sorting and filter parameters of list
+// endpoints from the URL query.
+package listquery
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// Options reads limit, cursor and sort. A leading "-" in sort means
+// descending order, e.g. ?sort=-capacity.
+func Options(q url.Values) (storage.ListOptions, error) {
+	var opts storage.ListOptions
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return opts, fmt.Errorf("invalid limit %q", v)
+		}
+		opts.Limit = limit
+	}
+
+	opts.Cursor = q.Get("cursor")
+
+	sort := q.Get("sort")
+	if strings.HasPrefix(sort, "-") {
+		opts.Desc = true
+		sort = sort[1:]
+	}
+	opts.SortBy = sort
+
+	return opts, nil
+}
+
+// Int64 reads an exact value and bounds for the field from
+// ?field=, ?field_gte= and ?field_lte=.
+func Int64(q url.Values, field string) (storage.Int64Filter, error) {
+	return int64Filter(q, field, parseInt64)
+}
+
+// Bytes is Int64 for sizes such as ?capacity_gte=16GB. Values without a
+// unit are read in unit, the unit the field had before sizes carried one.
+func Bytes(q url.Values, field, unit string) (storage.Int64Filter, error) {
+	return int64Filter(q, field, func(v string) (int64, error) {
+		b, err := units.ParseBytes(units.DefaultUnit(v, unit))
+		return int64(b), err
+	})
+}
+
+// Hertz is Bytes for frequencies such as ?base_clock_gte=3.5GHz.
+func Hertz(q url.Values, field, unit string) (storage.Int64Filter, error) {
+	return int64Filter(q, field, func(v string) (int64, error) {
+		h, err := units.ParseHertz(units.DefaultUnit(v, unit))
+		return int64(h), err
+	})
+}
+
+func int64Filter(q url.Values, field string, parse func(string) (int64, error)) (storage.Int64Filter, error) {
+	var f storage.Int64Filter
+	var err error
+
+	if f.Eq, err = optional(q, field, parse); err != nil {
+		return f, err
+	}
+	if f.GTE, err = optional(q, field+"_gte", parse); err != nil {
+		return f, err
+	}
+	if f.LTE, err = optional(q, field+"_lte", parse); err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
+
+// OptionalInt64 returns nil if the parameter is not set.
+func OptionalInt64(q url.Values, name string) (*int64, error) {
+	return optional(q, name, parseInt64)
+}
+
+func parseInt64(v string) (int64, error) {
+	return strconv.ParseInt(v, 10, 64)
+}
+
+func optional(q url.Values, name string, parse func(string) (int64, error)) (*int64, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	n, err := parse(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", name, v)
+	}
+
+	return &n, nil
+}
@@ -0,0 +1,52 @@
+// Package mergepatch implements JSON Merge Patch as described in RFC 7386.
+package mergepatch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Apply applies the merge patch to the original JSON document and returns
+// the patched document.
+func Apply(original, patch []byte) ([]byte, error) {
+	const op = "lib.api.mergepatch.Apply"
+
+	var target any
+	if err := json.Unmarshal(original, &target); err != nil {
+		return nil, fmt.Errorf("%s: decode document: %w", op, err)
+	}
+
+	var p any
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%s: decode patch: %w", op, err)
+	}
+
+	res, err := json.Marshal(merge(target, p))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = merge(t[k], v)
+	}
+
+	return t
+}
@@ -0,0 +1,65 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	// the examples of RFC 7386 appendix A and a few of their combinations
+	tests := []struct {
+		name     string
+		original string
+		patch    string
+		want     string
+	}{
+		{"replace member", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"add member", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null deletes member", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"null keeps other members", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"null of missing member", `{"a":"b"}`, `{"c":null}`, `{"a":"b"}`},
+		{"array replaces string", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"string replaces array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"nested object merges", `{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{"nested null deletes", `{"a":{"b":"c","d":"e"}}`, `{"a":{"d":null}}`, `{"a":{"b":"c"}}`},
+		{"arrays are replaced whole", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"array document replaced", `["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{"object replaces array document", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"null patch", `{"a":"foo"}`, `null`, `null`},
+		{"string patch", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null in original kept", `{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{"object on array document", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"nested object on missing member", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"empty patch", `{"a":"b"}`, `{}`, `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.original), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+
+			var gotDoc, wantDoc any
+			if err := json.Unmarshal(got, &gotDoc); err != nil {
+				t.Fatalf("Apply() = %s: %v", got, err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantDoc); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(gotDoc, wantDoc) {
+				t.Errorf("Apply(%s, %s) = %s, want %s", tt.original, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyInvalidJSON(t *testing.T) {
+	if _, err := Apply([]byte(`{"a":`), []byte(`{}`)); err == nil {
+		t.Error("Apply() with invalid document succeeded")
+	}
+	if _, err := Apply([]byte(`{}`), []byte(`{"a":`)); err == nil {
+		t.Error("Apply() with invalid patch succeeded")
+	}
+}
@@ -0,0 +1,250 @@
+// Package request decodes JSON request bodies. Unlike render.DecodeJSON it
+// tells which field a value failed to decode in, encoding/json leaves that
+// out for errors returned by UnmarshalJSON.
+//
+// Sizes and clocks were plain numbers before package units. A field tagged
+// with the unit it had then reads such numbers in it:
+//
+//	Capacity units.Bytes `json:"capacity" unit:"GiB"`
+//
+// so {"capacity": 16} decodes as {"capacity": "16GiB"}.
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+)
+
+// FieldError is a field of the body that failed to decode. Field is its
+// path in the body, e.g. "ram[0].capacity".
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeJSON decodes the JSON in r into v.
+func DecodeJSON(r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return Unmarshal(data, v)
+}
+
+// Unmarshal is json.Unmarshal returning a *FieldError when a field's
+// UnmarshalJSON fails.
+func Unmarshal(data []byte, v any) error {
+	data = withUnits(data, reflect.TypeOf(v))
+
+	err := json.NewDecoder(bytes.NewReader(data)).Decode(v)
+	if err == nil {
+		return nil
+	}
+	if field, ferr := locate(data, reflect.TypeOf(v), ""); ferr != nil && field != "" {
+		return &FieldError{Field: field, Err: ferr}
+	}
+
+	return err
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// locate decodes data into a fresh t field by field and returns the path
+// of the first field whose UnmarshalJSON fails, fields are tried in the
+// order t declares them.
+func locate(data []byte, t reflect.Type, path string) (string, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return "", nil
+	}
+
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		u := reflect.New(t).Interface().(json.Unmarshaler)
+		if err := u.UnmarshalJSON(data); err != nil {
+			return path, err
+		}
+		return "", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return "", nil
+		}
+		return locateFields(obj, t, path)
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return "", nil
+		}
+		for i, item := range items {
+			if field, err := locate(item, t.Elem(), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return field, err
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// locateFields is locate for the fields of struct t found in obj.
+func locateFields(obj map[string]json.RawMessage, t reflect.Type, path string) (string, error) {
+	for _, f := range fields(t) {
+		key, ok := lookup(obj, f.name)
+		if !ok {
+			continue
+		}
+		if field, err := locate(obj[key], f.Type, join(path, f.name)); err != nil {
+			return field, err
+		}
+	}
+
+	return "", nil
+}
+
+// withUnits returns data with the numbers in fields of t tagged with a unit
+// turned into strings in that unit. Data that is not valid JSON is returned
+// as is for the decoder to report.
+func withUnits(data []byte, t reflect.Type) []byte {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var doc any
+	if d.Decode(&doc) != nil || !addUnits(doc, t) {
+		return data
+	}
+
+	res, err := json.Marshal(doc)
+	if err != nil {
+		return data
+	}
+
+	return res
+}
+
+// addUnits is withUnits for the decoded document doc, it reports whether
+// doc changed.
+func addUnits(doc any, t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	changed := false
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := doc.(map[string]any)
+		if !ok {
+			return false
+		}
+		for _, f := range fields(t) {
+			key, ok := lookup(obj, f.name)
+			if !ok {
+				continue
+			}
+			unit := f.Tag.Get("unit")
+			if unit == "" {
+				changed = addUnits(obj[key], f.Type) || changed
+				continue
+			}
+			switch v := obj[key].(type) {
+			case json.Number:
+				obj[key] = units.DefaultUnit(v.String(), unit)
+				changed = true
+			case string:
+				if s := units.DefaultUnit(v, unit); s != v {
+					obj[key] = s
+					changed = true
+				}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := doc.([]any)
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			changed = addUnits(item, t.Elem()) || changed
+		}
+	}
+
+	return changed
+}
+
+// field is a struct field with its name in JSON.
+type field struct {
+	reflect.StructField
+	name string
+}
+
+// fields lists the fields of struct t set from JSON. Fields of embedded
+// structs count as t's own, as in encoding/json.
+func fields(t reflect.Type) []field {
+	var res []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			res = append(res, fields(ft)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		res = append(res, field{StructField: f, name: name})
+	}
+
+	return res
+}
+
+// lookup finds the key of obj matching name, preferring an exact match but
+// ignoring case as encoding/json does.
+func lookup[V any](obj map[string]V, name string) (string, bool) {
+	if _, ok := obj[name]; ok {
+		return name, true
+	}
+	for k := range obj {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+
+	return "", false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,127 @@
+package request
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+)
+
+type Details struct {
+	Memory units.Bytes `json:"memory"`
+}
+
+type testPart struct {
+	ID       int64       `json:"id"`
+	Capacity units.Bytes `json:"capacity"`
+	*Details
+}
+
+type testRequest struct {
+	Name  string      `json:"name"`
+	Clock units.Hertz `json:"base_clock"`
+	Parts []testPart  `json:"parts"`
+}
+
+func TestUnmarshalNamesField(t *testing.T) {
+	tests := []struct {
+		body  string
+		field string
+	}{
+		{`{"base_clock": 3600}`, "base_clock"},
+		{`{"base_clock": "3.6GB"}`, "base_clock"},
+		{`{"parts": [{"capacity": "16GB"}, {"capacity": 16}]}`, "parts[1].capacity"},
+		{`{"parts": [{"memory": "8"}]}`, "parts[0].memory"},
+		{`{"Base_Clock": "fast"}`, "base_clock"},
+	}
+	for _, tt := range tests {
+		var req testRequest
+		err := Unmarshal([]byte(tt.body), &req)
+
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Errorf("Unmarshal(%s) error = %v, want a FieldError", tt.body, err)
+			continue
+		}
+		if fieldErr.Field != tt.field {
+			t.Errorf("Unmarshal(%s) field = %q, want %q", tt.body, fieldErr.Field, tt.field)
+		}
+	}
+}
+
+func TestUnmarshalOtherErrors(t *testing.T) {
+	for _, body := range []string{`{"name": 1}`, `{"parts": {}}`, `{`} {
+		var req testRequest
+		err := Unmarshal([]byte(body), &req)
+
+		var fieldErr *FieldError
+		if err == nil || errors.As(err, &fieldErr) {
+			t.Errorf("Unmarshal(%s) error = %v, want a plain decode error", body, err)
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	var req testRequest
+	body := `{"name": "x", "base_clock": "3.6GHz", "parts": [{"id": 1, "capacity": "16GiB", "memory": "8GB"}]}`
+	if err := DecodeJSON(strings.NewReader(body), &req); err != nil {
+		t.Fatalf("DecodeJSON() error = %v", err)
+	}
+	if req.Clock != 3600*units.MHz || req.Parts[0].Capacity != 16*units.GiB || req.Parts[0].Memory != 8*units.GB {
+		t.Errorf("DecodeJSON() = %+v", req)
+	}
+}
+
+type LegacyDetails struct {
+	Memory units.Bytes `json:"memory" unit:"GB"`
+}
+
+type legacyPart struct {
+	Capacity units.Bytes `json:"capacity" unit:"GiB"`
+	*LegacyDetails
+}
+
+type legacyRequest struct {
+	Clock units.Hertz  `json:"base_clock" unit:"MHz"`
+	Parts []legacyPart `json:"parts"`
+}
+
+func TestUnmarshalLegacyUnits(t *testing.T) {
+	tests := []struct {
+		body string
+		want legacyRequest
+	}{
+		{
+			`{"base_clock": 3600, "parts": [{"capacity": 16, "memory": 8}]}`,
+			legacyRequest{Clock: 3600 * units.MHz, Parts: []legacyPart{{16 * units.GiB, &LegacyDetails{8 * units.GB}}}},
+		},
+		{
+			`{"Base_Clock": "3600", "parts": [{"capacity": 1.5}]}`,
+			legacyRequest{Clock: 3600 * units.MHz, Parts: []legacyPart{{Capacity: 1536 * units.MiB}}},
+		},
+		{
+			`{"base_clock": "3.6GHz", "parts": [{"capacity": "16GB", "memory": {"bytes": 512}}]}`,
+			legacyRequest{Clock: 3600 * units.MHz, Parts: []legacyPart{{16 * units.GB, &LegacyDetails{512}}}},
+		},
+	}
+	for _, tt := range tests {
+		var req legacyRequest
+		if err := Unmarshal([]byte(tt.body), &req); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", tt.body, err)
+			continue
+		}
+		if !reflect.DeepEqual(req, tt.want) {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.body, req, tt.want)
+		}
+	}
+
+	var req legacyRequest
+	err := Unmarshal([]byte(`{"parts": [{"capacity": -16}]}`), &req)
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "parts[0].capacity" {
+		t.Errorf("Unmarshal() negative error = %v, want a FieldError on parts[0].capacity", err)
+	}
+}
@@ -0,0 +1,134 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+)
+
+// ProblemContentType is the media type of Problem.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details error. It is sent instead of an
+// error Response to clients accepting ProblemContentType:
+//
+//	{
+//		"type": "about:blank",
+//		"title": "Unprocessable Entity",
+//		"status": 422,
+//		"detail": "field name is a required field",
+//		"instance": "host/XyZ-000042",
+//		"invalid_params": [{"name": "name", "reason": "is a required field"}]
+//	}
+//
+// Fields of the handler response besides status and error, such as the id
+// of the existing resource on a conflict, are kept as extension members.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Instance is the request id.
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam is a field of the request body failing validation. Name is
+// its path in the body, e.g. "ram[0].id".
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// NewProblem describes the error res answering r with status.
+func NewProblem(r *http.Request, status int, res Response) Problem {
+	return Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        res.Error,
+		Instance:      middleware.GetReqID(r.Context()),
+		InvalidParams: res.invalidParams,
+	}
+}
+
+// failure is implemented by Response and the handler responses embedding it.
+type failure interface {
+	response() Response
+}
+
+func (rs Response) response() Response {
+	return rs
+}
+
+// Respond replaces render.Respond. Errors rendered with render.Render go out
+// as a Problem to clients accepting ProblemContentType, everything else as
+// plain JSON.
+func Respond(w http.ResponseWriter, r *http.Request, v any) {
+	f, ok := v.(failure)
+	if !ok || f.response().Status != StatusError || !acceptsProblem(r) {
+		render.JSON(w, r, v)
+		return
+	}
+
+	res := f.response()
+	status := res.code
+	if status == 0 {
+		status, _ = r.Context().Value(render.StatusCtxKey).(int)
+	}
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	body, err := problemJSON(v, NewProblem(r, status, res))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// acceptsProblem reports whether the Accept header of r lists
+// ProblemContentType.
+func acceptsProblem(r *http.Request) bool {
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, _ := strings.Cut(accepted, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), ProblemContentType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// problemJSON encodes p with the fields of v it does not replace as
+// extension members.
+func problemJSON(v any, p Problem) ([]byte, error) {
+	members := map[string]json.RawMessage{}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	delete(members, "status")
+	delete(members, "error")
+
+	data, err = json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(members)
+}
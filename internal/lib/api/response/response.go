@@ -1,15 +1,36 @@
 package response
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
+	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/lib/api/request"
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/storage"
 )
 
 type Response struct {
 	Status string `json:"status"` // Error, Ok
 	Error  string `json:"error,omitempty"`
+
+	// code is the HTTP status render.Render answers with, zero keeps 200.
+	code int
+	// invalidParams are the fields failing validation, see Problem.
+	invalidParams []InvalidParam
+}
+
+// Render implements render.Renderer, it sets the HTTP status of the
+// response. Types embedding Response inherit it.
+func (rs Response) Render(w http.ResponseWriter, r *http.Request) error {
+	if rs.code != 0 {
+		render.Status(r, rs.code)
+	}
+
+	return nil
 }
 
 const (
@@ -23,6 +44,25 @@ func OK() Response {
 	}
 }
 
+// Page is the envelope for list responses. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Response
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func NewPage[T any](items []T, nextCursor string) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return Page[T]{
+		Response:   OK(),
+		Items:      items,
+		NextCursor: nextCursor,
+	}
+}
+
 func Error(msg string) Response {
 	return Response{
 		Status: StatusError,
@@ -30,30 +70,163 @@ func Error(msg string) Response {
 	}
 }
 
+func errorWithCode(code int, msg string) Response {
+	res := Error(msg)
+	res.code = code
+
+	return res
+}
+
+// BadRequest answers a malformed request, such as an invalid id or query
+// parameter, with 400.
+func BadRequest(msg string) Response {
+	return errorWithCode(http.StatusBadRequest, msg)
+}
+
+// DecodeError answers a request body that failed to decode. A field holding
+// an invalid size or frequency is answered like ValidationError with 422,
+// anything else, such as invalid JSON, with 400.
+func DecodeError(err error) Response {
+	var fieldErr *request.FieldError
+	var unitErr *units.SyntaxError
+	if !errors.As(err, &fieldErr) || !errors.As(fieldErr.Err, &unitErr) {
+		return BadRequest("failed to decode request body")
+	}
+
+	reason := fmt.Sprintf("is not a valid %s: %v", unitErr.Kind, unitErr.Err)
+	if errors.Is(unitErr, units.ErrNoUnit) {
+		reason = "needs a unit, e.g. " + unitExample[unitErr.Kind]
+	}
+
+	res := errorWithCode(http.StatusUnprocessableEntity, fmt.Sprintf("field %s %s", fieldErr.Field, reason))
+	res.invalidParams = []InvalidParam{{
+		Name:   fieldErr.Field,
+		Reason: reason,
+	}}
+
+	return res
+}
+
+var unitExample = map[string]string{
+	"size":      `"16GB"`,
+	"frequency": `"3.6GHz"`,
+}
+
+// AlreadyExists answers a duplicate with 409.
+func AlreadyExists(msg string) Response {
+	return errorWithCode(http.StatusConflict, msg)
+}
+
+// Conflict answers a request clashing with the stored state, such as
+// deleting a component a pc uses, with 409.
+func Conflict(msg string) Response {
+	return errorWithCode(http.StatusConflict, msg)
+}
+
+// NotFound answers a missing resource with 404.
+func NotFound(msg string) Response {
+	return errorWithCode(http.StatusNotFound, msg)
+}
+
+// Unprocessable answers a well formed request that cannot be applied, such
+// as one referring to a missing component, with 422.
+func Unprocessable(msg string) Response {
+	return errorWithCode(http.StatusUnprocessableEntity, msg)
+}
+
+// ComponentNotFound answers a pc referring to a missing component with 422,
+// naming the component if err is a storage.ComponentNotFoundError. A
+// component deleted between the check and the write only fails the foreign
+// key, storage cannot tell which one it was then.
+func ComponentNotFound(err error) Response {
+	var componentErr *storage.ComponentNotFoundError
+	if errors.As(err, &componentErr) {
+		return Unprocessable(componentErr.Error())
+	}
+
+	return Unprocessable(storage.ErrComponentNotFound.Error())
+}
+
+// StorageError answers a failed storage call with 500.
+func StorageError(msg string) Response {
+	return errorWithCode(http.StatusInternalServerError, msg)
+}
+
+// Timeout answers a storage call that ran out of time with 504.
+func Timeout() Response {
+	return errorWithCode(http.StatusGatewayTimeout, "request timed out")
+}
+
+// ValidationError answers a request failing validation with 422. Besides
+// the joined message it keeps every field for Problem.InvalidParams. Both
+// name fields by their path in the request body.
 func ValidationError(errs validator.ValidationErrors) Response {
 	var errMsgs []string
+	var params []InvalidParam
 
 	for _, err := range errs {
-		switch err.ActualTag() {
-		case "required":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is a required field", err.Field()))
-		case "name":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid name", err.Field()))
-		case "ram_id":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid ram id", err.Field()))
-		case "cpu_id":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid cpu id", err.Field()))
-		case "gpu_id":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid gpu id", err.Field()))
-		case "memory_id":
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not a valid memory id", err.Field()))
-		default:
-			errMsgs = append(errMsgs, fmt.Sprintf("field %s is not valid", err.Field()))
-		}
+		name, reason := paramName(err), validationReason(err)
+
+		errMsgs = append(errMsgs, fmt.Sprintf("field %s %s", name, reason))
+		params = append(params, InvalidParam{
+			Name:   name,
+			Reason: reason,
+		})
 	}
 
-	return Response{
-		Status: StatusError,
-		Error:  strings.Join(errMsgs, ", "),
+	res := errorWithCode(http.StatusUnprocessableEntity, strings.Join(errMsgs, ", "))
+	res.invalidParams = params
+
+	return res
+}
+
+func validationReason(err validator.FieldError) string {
+	switch err.ActualTag() {
+	case "required":
+		return "is a required field"
+	case "name":
+		return "is not a valid name"
+	case "ram_id":
+		return "is not a valid ram id"
+	case "cpu_id":
+		return "is not a valid cpu id"
+	case "gpu_id":
+		return "is not a valid gpu id"
+	case "memory_id":
+		return "is not a valid memory id"
+	case "ram_type":
+		return "is not a valid ram type"
+	case "storage_type":
+		return "is not a valid storage type"
+	case "storage_interface":
+		return "is not a valid storage interface"
+	case "storage_form_factor":
+		return "is not a valid storage form factor"
+	case "cpu_vendor":
+		return "is not a valid cpu vendor"
+	case "gpu_vendor":
+		return "is not a valid gpu chip vendor"
+	case "gpu_memory_type":
+		return "is not a valid gpu memory type"
+	case "peripheral_type":
+		return "is not a valid peripheral type"
+	case "peripheral_connection":
+		return "is not a valid peripheral connection"
+	case "gtefield":
+		return fmt.Sprintf("must not be less than %s", err.Param())
+	default:
+		return "is not valid"
+	}
+}
+
+// paramName is the path of the field in the request body, e.g.
+// "ram[0].id". The validator names fields after their json tags, the
+// namespace starts with the request type which is dropped.
+func paramName(err validator.FieldError) string {
+	_, name, ok := strings.Cut(err.Namespace(), ".")
+	if !ok {
+		return err.Field()
 	}
+
+	return name
 }
@@ -0,0 +1,206 @@
+// Package validate holds the validator shared by the handlers. On top of
+// the validator builtins it knows tags for the component enums:
+//
+//	ram_type              ram.Types
+//	storage_type          memory.StorageTypes
+//	storage_interface     memory.Interfaces
+//	storage_form_factor   memory.FormFactors
+//	cpu_vendor            cpu.Vendors
+//	gpu_vendor            gpu.ChipVendors
+//	gpu_memory_type       gpu.MemoryTypes
+//	peripheral_type       peripheral.Types
+//	peripheral_connection peripheral.Connections
+//
+// Fields are named after their json tags, FieldError.StructField has the Go
+// name. FieldError.Namespace is the path of the field in JSON, with embedded
+// structs left out as encoding/json flattens them. FieldError.Param of cross
+// field tags such as gtefield=Cores is the json name of the other field.
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
+var v = newValidator()
+
+// Struct validates s, errors are validator.ValidationErrors as with the
+// builtin validator.
+func Struct(s any) error {
+	err := v.Struct(s)
+
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+	root := reflect.TypeOf(s)
+	for i, fe := range errs {
+		e := fieldError{FieldError: fe, namespace: fe.Namespace(), param: fe.Param()}
+		if ns, ok := jsonNamespace(root, fe.StructNamespace()); ok {
+			e.namespace = ns
+		}
+		if crossFieldTags[fe.ActualTag()] {
+			if name := siblingName(root, fe.StructNamespace(), fe.Param()); name != "" {
+				e.param = name
+			}
+		}
+		errs[i] = e
+	}
+
+	return errs
+}
+
+// crossFieldTags are the builtin tags whose param names another field of
+// the same struct.
+var crossFieldTags = map[string]bool{
+	"eqfield":  true,
+	"nefield":  true,
+	"gtfield":  true,
+	"gtefield": true,
+	"ltfield":  true,
+	"ltefield": true,
+}
+
+// fieldError is a FieldError with the namespace and param named as in JSON.
+type fieldError struct {
+	validator.FieldError
+	namespace string
+	param     string
+}
+
+func (e fieldError) Namespace() string {
+	return e.namespace
+}
+
+func (e fieldError) Param() string {
+	return e.param
+}
+
+// jsonNamespace turns a validator struct namespace such as
+// "RequestBuild.RAM[0].RequestRAM.Name" starting at t into the path of the
+// field in JSON, "RequestBuild.ram[0].name". It reports false if the
+// namespace does not match t.
+func jsonNamespace(t reflect.Type, namespace string) (string, bool) {
+	path := strings.Split(namespace, ".")
+	res := []string{path[0]}
+
+	t = elem(t)
+	for _, segment := range path[1:] {
+		name, index, indexed := strings.Cut(segment, "[")
+		if t.Kind() != reflect.Struct {
+			return "", false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return "", false
+		}
+		t = elem(f.Type)
+
+		if json := jsonName(f); json != "" {
+			name = json
+		} else if f.Anonymous {
+			continue
+		}
+		if indexed {
+			name += "[" + index
+		}
+		res = append(res, name)
+	}
+
+	return strings.Join(res, "."), true
+}
+
+// siblingName returns the json name of the field called field in the struct
+// holding the field at namespace, a validator struct namespace such as
+// "RequestPC.RAM[0].ID" starting at t. It returns "" if there is none.
+func siblingName(t reflect.Type, namespace, field string) string {
+	path := strings.Split(namespace, ".")
+	if len(path) < 2 {
+		return ""
+	}
+
+	t = elem(t)
+	for _, name := range path[1 : len(path)-1] {
+		name, _, _ = strings.Cut(name, "[")
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return ""
+		}
+		t = elem(f.Type)
+	}
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return ""
+	}
+
+	return jsonName(f)
+}
+
+// elem strips pointers and containers off t down to the element type.
+func elem(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// jsonName is the name of f in JSON, "" if it has no json tag.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+
+	return name
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterTagNameFunc(jsonName)
+
+	enums := map[string][]string{
+		"ram_type":            ram.Types,
+		"storage_type":        memory.StorageTypes,
+		"storage_interface":   memory.Interfaces,
+		"storage_form_factor": memory.FormFactors,
+
+		"cpu_vendor":      cpu.Vendors,
+		"gpu_vendor":      gpu.ChipVendors,
+		"gpu_memory_type": gpu.MemoryTypes,
+
+		"peripheral_type":       peripheral.Types,
+		"peripheral_connection": peripheral.Connections,
+	}
+	for tag, values := range enums {
+		if err := v.RegisterValidation(tag, oneOf(values)); err != nil {
+			panic(err)
+		}
+	}
+
+	return v
+}
+
+// oneOf accepts strings equal to one of values.
+func oneOf(values []string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return slices.Contains(values, fl.Field().String())
+	}
+}
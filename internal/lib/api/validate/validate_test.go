@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type testPart struct {
+	ID int64 `json:"id" validate:"required"`
+}
+
+type testDetails struct {
+	Name string `json:"name" validate:"required"`
+	Min  int64  `json:"min_speed"`
+	Max  int64  `json:"max_speed" validate:"gtefield=Min"`
+}
+
+type testRequest struct {
+	CPUID int64      `json:"cpu_id" validate:"required"`
+	Parts []testPart `json:"parts" validate:"dive"`
+	*testDetails
+}
+
+func TestStructNamesFieldsAsInJSON(t *testing.T) {
+	req := testRequest{
+		Parts:       []testPart{{ID: 1}, {}},
+		testDetails: &testDetails{Min: 5, Max: 1},
+	}
+
+	err := Struct(req)
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		t.Fatalf("Struct() error = %v, want validator.ValidationErrors", err)
+	}
+
+	want := []struct {
+		namespace string
+		param     string
+	}{
+		{"testRequest.cpu_id", ""},
+		{"testRequest.parts[1].id", ""},
+		{"testRequest.name", ""},
+		{"testRequest.max_speed", "min_speed"},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Struct() returned %d errors, want %d: %v", len(errs), len(want), errs)
+	}
+	for i, w := range want {
+		if got := errs[i].Namespace(); got != w.namespace {
+			t.Errorf("errs[%d].Namespace() = %q, want %q", i, got, w.namespace)
+		}
+		if got := errs[i].Param(); got != w.param {
+			t.Errorf("errs[%d].Param() = %q, want %q", i, got, w.param)
+		}
+	}
+}
+
+func TestStructAcceptsValid(t *testing.T) {
+	req := testRequest{
+		CPUID:       1,
+		Parts:       []testPart{{ID: 1}},
+		testDetails: &testDetails{Name: "x", Min: 1, Max: 5},
+	}
+
+	if err := Struct(req); err != nil {
+		t.Fatalf("Struct() error = %v", err)
+	}
+}
+
+func TestEnumTags(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value string
+		valid bool
+	}{
+		{"ram_type", "DDR5 ECC", true},
+		{"ram_type", "ddr5", false},
+		{"cpu_vendor", "AMD", true},
+		{"cpu_vendor", "ARM", false},
+		{"gpu_vendor", "NVIDIA", true},
+		{"gpu_vendor", "Nvidia", false},
+		{"gpu_memory_type", "GDDR6X", true},
+		{"gpu_memory_type", "DDR5", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag+" "+tt.value, func(t *testing.T) {
+			err := v.Var(tt.value, tt.tag)
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("Var(%q, %q) error = %v, want valid %v", tt.value, tt.tag, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Package units stores sizes and frequencies in their base unit, bytes and
+// hertz, so 16 GiB of ram is the same value whoever saved it.
+//
+// Both types decode from a string with a unit such as "16GB" or "3.6 GHz",
+// or the object they encode to:
+//
+//	{"bytes": 17179869184, "human": "16 GiB"}
+//	{"hertz": 3600000000, "human": "3.6 GHz"}
+//
+// A number without a unit is rejected rather than guessed, clients used to
+// send sizes in GB or GiB and clocks in MHz depending on the field. Callers
+// knowing the field read such numbers in its old unit with DefaultUnit.
+package units
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Bytes is a size in bytes.
+type Bytes int64
+
+const (
+	B   Bytes = 1
+	KB        = 1000 * B
+	MB        = 1000 * KB
+	GB        = 1000 * MB
+	TB        = 1000 * GB
+	KiB       = 1024 * B
+	MiB       = 1024 * KiB
+	GiB       = 1024 * MiB
+	TiB       = 1024 * GiB
+)
+
+var byteUnits = []unit{
+	{"TB", int64(TB), false}, {"TiB", int64(TiB), true},
+	{"GB", int64(GB), false}, {"GiB", int64(GiB), true},
+	{"MB", int64(MB), false}, {"MiB", int64(MiB), true},
+	{"KB", int64(KB), false}, {"KiB", int64(KiB), true},
+	{"B", int64(B), false},
+}
+
+// ParseBytes reads a size like "16GB", "512 MiB" or "1.5TB". Units are
+// case insensitive, KB to TB are decimal and KiB to TiB binary.
+func ParseBytes(s string) (Bytes, error) {
+	n, err := parse(s, byteUnits)
+	if err != nil {
+		return 0, &SyntaxError{Kind: "size", Value: strconv.Quote(s), Err: err}
+	}
+
+	return Bytes(n), nil
+}
+
+// String renders the size in the unit that shows it the shortest, e.g.
+// "2 TB", "1.5 GB" or "16 GiB".
+func (b Bytes) String() string {
+	return format(int64(b), byteUnits)
+}
+
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Bytes int64  `json:"bytes"`
+		Human string `json:"human"`
+	}{int64(b), b.String()})
+}
+
+func (b *Bytes) UnmarshalJSON(data []byte) error {
+	n, err := unmarshal(data, "bytes", byteUnits)
+	if err != nil {
+		return &SyntaxError{Kind: "size", Value: string(bytes.TrimSpace(data)), Err: err}
+	}
+	if n != nil {
+		*b = Bytes(*n)
+	}
+
+	return nil
+}
+
+// Hertz is a frequency in hertz.
+type Hertz int64
+
+const (
+	Hz  Hertz = 1
+	KHz       = 1000 * Hz
+	MHz       = 1000 * KHz
+	GHz       = 1000 * MHz
+)
+
+var hertzUnits = []unit{
+	{"GHz", int64(GHz), false},
+	{"MHz", int64(MHz), false},
+	{"kHz", int64(KHz), false},
+	{"Hz", int64(Hz), false},
+}
+
+// ParseHertz reads a frequency like "3.6GHz" or "2505 MHz".
+func ParseHertz(s string) (Hertz, error) {
+	n, err := parse(s, hertzUnits)
+	if err != nil {
+		return 0, &SyntaxError{Kind: "frequency", Value: strconv.Quote(s), Err: err}
+	}
+
+	return Hertz(n), nil
+}
+
+// String renders the frequency in the unit that shows it the shortest, e.g.
+// "3.6 GHz" or "2505 MHz".
+func (h Hertz) String() string {
+	return format(int64(h), hertzUnits)
+}
+
+func (h Hertz) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Hertz int64  `json:"hertz"`
+		Human string `json:"human"`
+	}{int64(h), h.String()})
+}
+
+func (h *Hertz) UnmarshalJSON(data []byte) error {
+	n, err := unmarshal(data, "hertz", hertzUnits)
+	if err != nil {
+		return &SyntaxError{Kind: "frequency", Value: string(bytes.TrimSpace(data)), Err: err}
+	}
+	if n != nil {
+		*h = Hertz(*n)
+	}
+
+	return nil
+}
+
+// SyntaxError is a size or frequency that cannot be read.
+type SyntaxError struct {
+	// Kind is "size" or "frequency".
+	Kind string
+	// Value is the input, quoted if it was a string.
+	Value string
+	Err   error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid %s %s: %v", e.Kind, e.Value, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// ErrNoUnit is a number given without a unit.
+var ErrNoUnit = errors.New("missing unit")
+
+// DefaultUnit appends unit to s if s is a number without one, so "16" with
+// "GiB" reads as "16GiB". Anything else is returned as is.
+func DefaultUnit(s, unit string) string {
+	if m := quantity.FindStringSubmatch(s); m != nil && m[2] == "" {
+		return m[1] + unit
+	}
+
+	return s
+}
+
+type unit struct {
+	name   string
+	size   int64
+	binary bool
+}
+
+var quantity = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s*([A-Za-z]*)\s*$`)
+
+func parse(s string, units []unit) (int64, error) {
+	m := quantity.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("expected a number and a unit")
+	}
+	if m[2] == "" {
+		return 0, ErrNoUnit
+	}
+
+	var size int64
+	for _, u := range units {
+		if strings.EqualFold(u.name, m[2]) {
+			size = u.size
+			break
+		}
+	}
+	if size == 0 {
+		return 0, fmt.Errorf("unknown unit %q", m[2])
+	}
+
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, err
+	}
+	v = math.Round(v * float64(size))
+	if v >= math.MaxInt64 {
+		return 0, fmt.Errorf("out of range")
+	}
+
+	return int64(v), nil
+}
+
+// format renders n in the unit giving the shortest exact number with up to
+// four digits and three decimals, the first of units on a tie. Units are
+// ordered from the largest. Values no unit shows exactly are rounded in the
+// largest decimal unit not above them.
+func format(n int64, units []unit) string {
+	base := units[len(units)-1]
+	if n < base.size {
+		return strconv.FormatInt(n, 10) + " " + base.name
+	}
+
+	var best, name string
+	for _, u := range units {
+		if n < u.size || n/u.size >= 10000 || n%u.size*1000%u.size != 0 {
+			continue
+		}
+		if v := number(n, u); best == "" || len(v) < len(best) {
+			best, name = v, u.name
+		}
+	}
+	if best != "" {
+		return best + " " + name
+	}
+
+	for _, u := range units {
+		if n >= u.size && !u.binary {
+			return number(n, u) + " " + u.name
+		}
+	}
+
+	return strconv.FormatInt(n, 10) + " " + base.name
+}
+
+// number is n in u with up to three decimals.
+func number(n int64, u unit) string {
+	v := strconv.FormatFloat(float64(n)/float64(u.size), 'f', 3, 64)
+
+	return strings.TrimRight(strings.TrimRight(v, "0"), ".")
+}
+
+// unmarshal decodes a JSON string or object holding the value under key.
+// It returns nil for null.
+func unmarshal(data []byte, key string, units []unit) (*int64, error) {
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil, nil
+	case bytes.HasPrefix(data, []byte(`"`)):
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		n, err := parse(s, units)
+		if err != nil {
+			return nil, err
+		}
+		return &n, nil
+	case bytes.HasPrefix(data, []byte("{")):
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return nil, err
+		}
+		raw, ok := obj[key]
+		if !ok {
+			return nil, fmt.Errorf("missing %q", key)
+		}
+		var n int64
+		if err := json.Unmarshal(raw, &n); err != nil {
+			return nil, fmt.Errorf("%q: %w", key, err)
+		}
+		return &n, nil
+	case len(data) > 0 && (data[0] == '-' || data[0] >= '0' && data[0] <= '9'):
+		return nil, ErrNoUnit
+	}
+
+	return nil, fmt.Errorf("expected a string or an object")
+}
@@ -0,0 +1,224 @@
+package units
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Bytes
+		wantErr bool
+	}{
+		{in: "16GB", want: 16 * GB},
+		{in: "16GiB", want: 16 * GiB},
+		{in: "16 GiB", want: 16 * GiB},
+		{in: " 512 mib ", want: 512 * MiB},
+		{in: "1.5TB", want: 1500 * GB},
+		{in: "0.5KiB", want: 512},
+		{in: "100B", want: 100},
+		{in: "16", wantErr: true},
+		{in: "16 GiBs", wantErr: true},
+		{in: "16GHz", wantErr: true},
+		{in: "GB", wantErr: true},
+		{in: "-1GB", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "99999999TiB", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseBytes(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseBytes(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseBytes(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseHertz(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Hertz
+		wantErr bool
+	}{
+		{in: "3.6GHz", want: 3600 * MHz},
+		{in: "3.5 ghz", want: 3500 * MHz},
+		{in: "2505 MHz", want: 2505 * MHz},
+		{in: "32kHz", want: 32 * KHz},
+		{in: "50Hz", want: 50},
+		{in: "3600", wantErr: true},
+		{in: "3.6 GB", wantErr: true},
+		{in: "3,6GHz", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHertz(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseHertz(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseHertz(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseWithoutUnit(t *testing.T) {
+	_, err := ParseBytes("16")
+
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) || syntaxErr.Kind != "size" {
+		t.Fatalf("ParseBytes() error = %v, want a size SyntaxError", err)
+	}
+	if !errors.Is(err, ErrNoUnit) {
+		t.Errorf("ParseBytes() error = %v, want ErrNoUnit", err)
+	}
+}
+
+func TestBytesString(t *testing.T) {
+	tests := []struct {
+		in   Bytes
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{16 * GiB, "16 GiB"},
+		{16 * GB, "16 GB"},
+		{512 * MiB, "512 MiB"},
+		{1500 * MB, "1.5 GB"},
+		{2 * TB, "2 TB"},
+		{16*GiB + 1, "17.18 GB"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Bytes(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestHertzString(t *testing.T) {
+	tests := []struct {
+		in   Hertz
+		want string
+	}{
+		{0, "0 Hz"},
+		{3600 * MHz, "3.6 GHz"},
+		{2505 * MHz, "2505 MHz"},
+		{5 * GHz, "5 GHz"},
+		{32 * KHz, "32 kHz"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Hertz(%d).String() = %q, want %q", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestBytesJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Bytes
+		wantErr error
+	}{
+		{in: `"16GiB"`, want: 16 * GiB},
+		{in: `{"bytes": 17179869184, "human": "16 GiB"}`, want: 16 * GiB},
+		{in: `{"bytes": 17179869184}`, want: 16 * GiB},
+		{in: `null`, want: 0},
+		{in: `16`, wantErr: ErrNoUnit},
+		{in: `"16"`, wantErr: ErrNoUnit},
+		{in: `"sixteen"`, wantErr: &SyntaxError{}},
+		{in: `{"human": "16 GiB"}`, wantErr: &SyntaxError{}},
+		{in: `true`, wantErr: &SyntaxError{}},
+	}
+	for _, tt := range tests {
+		var got Bytes
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if tt.wantErr == nil {
+			if err != nil {
+				t.Errorf("Unmarshal(%s) error = %v", tt.in, err)
+			} else if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+			}
+			continue
+		}
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || syntaxErr.Kind != "size" {
+			t.Errorf("Unmarshal(%s) error = %v, want a size SyntaxError", tt.in, err)
+		}
+		if tt.wantErr == ErrNoUnit && !errors.Is(err, ErrNoUnit) {
+			t.Errorf("Unmarshal(%s) error = %v, want ErrNoUnit", tt.in, err)
+		}
+	}
+}
+
+func TestHertzJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Hertz
+		wantErr bool
+	}{
+		{in: `"3.6GHz"`, want: 3600 * MHz},
+		{in: `{"hertz": 3600000000, "human": "3.6 GHz"}`, want: 3600 * MHz},
+		{in: `3600`, wantErr: true},
+		{in: `"3.6 GB"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var got Hertz
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type part struct {
+		Capacity Bytes `json:"capacity"`
+		Clock    Hertz `json:"clock"`
+	}
+
+	for _, in := range []part{
+		{Capacity: 16 * GiB, Clock: 3600 * MHz},
+		{Capacity: 2 * TB, Clock: 2505 * MHz},
+		{Capacity: 16*GiB + 1, Clock: 1},
+		{},
+	} {
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal(%+v) error = %v", in, err)
+		}
+
+		var out part
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Unmarshal(%s) error = %v", data, err)
+		}
+		if out != in {
+			t.Errorf("round trip of %+v = %+v via %s", in, out, data)
+		}
+	}
+}
+
+func TestDefaultUnit(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"16", "16GiB"},
+		{" 1.5 ", "1.5GiB"},
+		{"16MB", "16MB"},
+		{"16 GB", "16 GB"},
+		{"-16", "-16"},
+		{"fast", "fast"},
+	}
+	for _, tt := range tests {
+		if got := DefaultUnit(tt.in, "GiB"); got != tt.want {
+			t.Errorf("DefaultUnit(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package cooler
+
+const (
+	Air    = "air"
+	Liquid = "liquid"
+)
+
+type Cooler struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// TDP is the heat in watts the cooler is rated to dissipate.
+	TDP int64 `json:"tdp"`
+	// Height in millimeters, zero for liquid coolers.
+	Height int64 `json:"height"`
+}
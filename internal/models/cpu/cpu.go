@@ -1,9 +1,30 @@
 package cpu
 
+import "github.com/r33ta/pc-database-manager/internal/lib/units"
+
+const (
+	Intel = "Intel"
+	AMD   = "AMD"
+)
+
+// Vendors lists the vendors accepted in CPU.Vendor.
+var Vendors = []string{Intel, AMD}
+
 type CPU struct {
-	ID        int64
-	Name      string
-	Cores     int64
-	Threads   int64
-	Frequency int64
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Vendor  string `json:"vendor"`
+	Socket  string `json:"socket"`
+	Cores   int64  `json:"cores"`
+	Threads int64  `json:"threads"`
+	// Architecture is the microarchitecture generation, e.g. "Zen 4" or
+	// "Raptor Lake".
+	Architecture string `json:"architecture"`
+	// BoostClock is zero for cpus without boost.
+	BaseClock  units.Hertz `json:"base_clock"`
+	BoostClock units.Hertz `json:"boost_clock"`
+	L3Cache    units.Bytes `json:"l3_cache"`
+	// TDP is the thermal design power in watts, zero when unknown.
+	TDP                int64 `json:"tdp"`
+	IntegratedGraphics bool  `json:"integrated_graphics"`
 }
@@ -1,9 +1,55 @@
 package gpu
 
+import "github.com/r33ta/pc-database-manager/internal/lib/units"
+
+const (
+	NVIDIA = "NVIDIA"
+	AMD    = "AMD"
+	Intel  = "Intel"
+)
+
+// ChipVendors lists the vendors accepted in GPU.ChipVendor.
+var ChipVendors = []string{NVIDIA, AMD, Intel}
+
+const (
+	GDDR5  = "GDDR5"
+	GDDR6  = "GDDR6"
+	GDDR6X = "GDDR6X"
+	GDDR7  = "GDDR7"
+	HBM2   = "HBM2"
+	HBM3   = "HBM3"
+)
+
+// MemoryTypes lists the video memory types accepted in GPU.MemoryType.
+var MemoryTypes = []string{GDDR5, GDDR6, GDDR6X, GDDR7, HBM2, HBM3}
+
 type GPU struct {
-	ID           int64
-	Name         string
-	Manufacturer string
-	Memory       int64
-	Frequency    int64
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// ChipVendor designed the graphics chip, Manufacturer is the board
+	// partner that built the card. Both are the same for reference cards.
+	ChipVendor   string      `json:"chip_vendor"`
+	Manufacturer string      `json:"manufacturer"`
+	Memory       units.Bytes `json:"memory"`
+	MemoryType   string      `json:"memory_type"`
+	// BusWidth is the memory bus width in bits.
+	BusWidth  int64       `json:"bus_width"`
+	Frequency units.Hertz `json:"frequency"`
+	// BoardPower is the total board power (TDP) in watts, zero when unknown.
+	BoardPower int64 `json:"board_power"`
+	// PCIeGen and PCIeLanes describe the host interface, e.g. 4 and 16 for
+	// a PCIe 4.0 x16 card.
+	PCIeGen   int64 `json:"pcie_gen"`
+	PCIeLanes int64 `json:"pcie_lanes"`
+	// Length is in mm, zero when unknown.
+	Length  int64   `json:"length"`
+	Outputs Outputs `json:"outputs"`
+}
+
+// Outputs counts the display outputs of a card by connector.
+type Outputs struct {
+	HDMI        int64 `json:"hdmi"`
+	DisplayPort int64 `json:"displayport"`
+	DVI         int64 `json:"dvi"`
+	USBC        int64 `json:"usb_c"`
 }
@@ -1,13 +1,60 @@
 package memory
 
+import (
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+)
+
+const (
+	SSD     = "SSD"
+	HDD     = "HDD"
+	SATASSD = "SATA SSD"
+	NVMe    = "NVMe"
+)
+
+// StorageTypes lists the types accepted in Memory.StorageType. SSD is kept
+// for drives saved before the interface was recorded.
+var StorageTypes = []string{SSD, HDD, SATASSD, NVMe}
+
 const (
-	SSD = "SSD"
-	HDD = "HDD"
+	SATA  = "SATA"
+	PCIe3 = "PCIe 3.0"
+	PCIe4 = "PCIe 4.0"
+	PCIe5 = "PCIe 5.0"
 )
 
+// Interfaces lists the values accepted in Memory.Interface.
+var Interfaces = []string{SATA, PCIe3, PCIe4, PCIe5}
+
+const (
+	Inch35      = "3.5 inch"
+	Inch25      = "2.5 inch"
+	M2Size2230  = "M.2 2230"
+	M2Size2242  = "M.2 2242"
+	M2Size2280  = "M.2 2280"
+	M2Size22110 = "M.2 22110"
+)
+
+// FormFactors lists the values accepted in Memory.FormFactor.
+var FormFactors = []string{Inch35, Inch25, M2Size2230, M2Size2242, M2Size2280, M2Size22110}
+
 type Memory struct {
-	ID          int64
-	Name        string
-	Capacity    int64
-	StorageType string
+	ID          int64       `json:"id"`
+	Name        string      `json:"name"`
+	Capacity    units.Bytes `json:"capacity"`
+	StorageType string      `json:"storage_type"`
+	Interface   string      `json:"interface"`
+	FormFactor  string      `json:"form_factor"`
+	// ReadSpeed and WriteSpeed are sequential speeds in MB/s.
+	ReadSpeed  int64 `json:"read_speed"`
+	WriteSpeed int64 `json:"write_speed"`
+	// TBW is the rated endurance in terabytes written, zero for hard drives
+	// and when unknown.
+	TBW int64 `json:"tbw"`
+}
+
+// IsM2 reports whether the drive has an m.2 form factor.
+func (m Memory) IsM2() bool {
+	return strings.HasPrefix(m.FormFactor, "M.2")
 }
@@ -0,0 +1,25 @@
+package motherboard
+
+import "github.com/r33ta/pc-database-manager/internal/lib/units"
+
+const (
+	EATX     = "E-ATX"
+	ATX      = "ATX"
+	MicroATX = "Micro-ATX"
+	MiniITX  = "Mini-ITX"
+)
+
+type Motherboard struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Socket     string `json:"socket"`
+	Chipset    string `json:"chipset"`
+	FormFactor string `json:"form_factor"`
+	// MemoryType is one of the ram.DDR* constants.
+	MemoryType string `json:"memory_type"`
+	RAMSlots   int64  `json:"ram_slots"`
+	// MaxMemory is the total ram capacity supported, zero means unknown.
+	MaxMemory units.Bytes `json:"max_memory"`
+	M2Slots   int64       `json:"m2_slots"`
+	SATAPorts int64       `json:"sata_ports"`
+}
@@ -1,10 +1,95 @@
 package pc
 
+import (
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
 type PC struct {
-	ID       int64
-	Name     string
-	RAMID    int64
-	CPUID    int64
-	GPUID    int64
-	MemoryID int64
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	CPUID  int64  `json:"cpu_id"`
+	RAM    []Part `json:"ram"`
+	GPU    []Part `json:"gpu"`
+	Memory []Part `json:"memory"`
+
+	// The remaining components are optional, zero means not recorded.
+	MotherboardID int64 `json:"motherboard_id,omitempty"`
+	PSUID         int64 `json:"psu_id,omitempty"`
+	CaseID        int64 `json:"case_id,omitempty"`
+	CoolerID      int64 `json:"cooler_id,omitempty"`
+
+	Peripherals []Part `json:"peripherals,omitempty"`
+}
+
+// Part references a component installed in a pc. Quantity counts identical
+// components sharing the same slot description, e.g. 4 DIMMs or 2 SSDs.
+type Part struct {
+	ID       int64  `json:"id"`
+	Quantity int64  `json:"quantity"`
+	Slot     string `json:"slot,omitempty"`
+}
+
+// Expanded is a PC with its components resolved.
+type Expanded struct {
+	ID     int64        `json:"id"`
+	Name   string       `json:"name"`
+	CPU    cpu.CPU      `json:"cpu"`
+	RAM    []RAMPart    `json:"ram"`
+	GPU    []GPUPart    `json:"gpu"`
+	Memory []MemoryPart `json:"memory"`
+
+	Motherboard *motherboard.Motherboard `json:"motherboard,omitempty"`
+	PSU         *psu.PSU                 `json:"psu,omitempty"`
+	Case        *pccase.Case             `json:"case,omitempty"`
+	Cooler      *cooler.Cooler           `json:"cooler,omitempty"`
+
+	Peripherals []PeripheralPart `json:"peripherals,omitempty"`
+}
+
+type RAMPart struct {
+	ram.RAM
+	Quantity int64  `json:"quantity"`
+	Slot     string `json:"slot,omitempty"`
+}
+
+type GPUPart struct {
+	gpu.GPU
+	Quantity int64  `json:"quantity"`
+	Slot     string `json:"slot,omitempty"`
+}
+
+type MemoryPart struct {
+	memory.Memory
+	Quantity int64  `json:"quantity"`
+	Slot     string `json:"slot,omitempty"`
+}
+
+type PeripheralPart struct {
+	peripheral.Peripheral
+	Quantity int64  `json:"quantity"`
+	Slot     string `json:"slot,omitempty"`
+}
+
+// Build describes a pc together with its components for saving in one go.
+// Components with a zero ID are created, the others must already exist.
+type Build struct {
+	Name   string
+	CPU    cpu.CPU
+	RAM    []RAMPart
+	GPU    []GPUPart
+	Memory []MemoryPart
+
+	// The remaining components are optional and left out when nil.
+	Motherboard *motherboard.Motherboard
+	PSU         *psu.PSU
+	Case        *pccase.Case
+	Cooler      *cooler.Cooler
 }
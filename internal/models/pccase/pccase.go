@@ -0,0 +1,13 @@
+// Package pccase holds the computer case model, case itself is a keyword.
+package pccase
+
+type Case struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// FormFactor is the largest motherboard form factor that fits, one of
+	// the motherboard constants.
+	FormFactor string `json:"form_factor"`
+	// MaxGPULength and MaxCoolerHeight are the clearances in millimeters.
+	MaxGPULength    int64 `json:"max_gpu_length"`
+	MaxCoolerHeight int64 `json:"max_cooler_height"`
+}
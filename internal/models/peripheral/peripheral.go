@@ -0,0 +1,99 @@
+package peripheral
+
+// Peripheral types.
+const (
+	Monitor  = "monitor"
+	Keyboard = "keyboard"
+	NIC      = "nic"
+	WiFi     = "wifi"
+)
+
+// Types lists the types accepted in Peripheral.Type.
+var Types = []string{Monitor, Keyboard, NIC, WiFi}
+
+// Connections, monitors use the display ones, network adapters PCIe or M.2.
+const (
+	USB         = "USB"
+	USBC        = "USB-C"
+	Bluetooth   = "Bluetooth"
+	Wireless    = "Wireless"
+	PCIe        = "PCIe"
+	M2          = "M.2"
+	HDMI        = "HDMI"
+	DisplayPort = "DisplayPort"
+)
+
+// Connections lists the connections accepted in Peripheral.Connection.
+var Connections = []string{USB, USBC, Bluetooth, Wireless, PCIe, M2, HDMI, DisplayPort}
+
+// Peripheral is a device attached to a pc. Only the details matching Type
+// are set, nics and wifi cards share Network.
+type Peripheral struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	Manufacturer string `json:"manufacturer"`
+	Connection   string `json:"connection"`
+
+	Monitor  *MonitorDetails  `json:"monitor,omitempty"`
+	Keyboard *KeyboardDetails `json:"keyboard,omitempty"`
+	Network  *NetworkDetails  `json:"network,omitempty"`
+}
+
+type MonitorDetails struct {
+	// Diagonal is in inches.
+	Diagonal float64 `json:"diagonal"`
+	// Resolution in pixels.
+	Width  int64 `json:"width"`
+	Height int64 `json:"height"`
+	// RefreshRate is in Hz.
+	RefreshRate int64  `json:"refresh_rate"`
+	Panel       string `json:"panel"`
+}
+
+type KeyboardDetails struct {
+	Layout   string `json:"layout"`
+	Switches string `json:"switches"`
+}
+
+type NetworkDetails struct {
+	// Speed is the fastest link rate in Mbit/s.
+	Speed int64 `json:"speed"`
+	Ports int64 `json:"ports"`
+	// Standard is e.g. "Wi-Fi 6E" or "10GBASE-T".
+	Standard  string `json:"standard"`
+	Bluetooth bool   `json:"bluetooth"`
+}
+
+// WithAllDetails returns a copy of p with the details of every type set,
+// zero for the ones p does not have. Storage keeps them all in one row.
+func (p Peripheral) WithAllDetails() Peripheral {
+	p.Monitor = details(p.Monitor)
+	p.Keyboard = details(p.Keyboard)
+	p.Network = details(p.Network)
+	return p
+}
+
+// WithOwnDetails returns a copy of p with only the details of its type.
+func (p Peripheral) WithOwnDetails() Peripheral {
+	p = p.WithAllDetails()
+	if p.Type != Monitor {
+		p.Monitor = nil
+	}
+	if p.Type != Keyboard {
+		p.Keyboard = nil
+	}
+	if p.Type != NIC && p.Type != WiFi {
+		p.Network = nil
+	}
+	return p
+}
+
+// details copies d, or returns zero details if d is nil.
+func details[T any](d *T) *T {
+	var res T
+	if d != nil {
+		res = *d
+	}
+	return &res
+}
@@ -0,0 +1,20 @@
+package psu
+
+// 80 PLUS efficiency ratings.
+const (
+	Standard = "80 PLUS"
+	Bronze   = "80 PLUS Bronze"
+	Silver   = "80 PLUS Silver"
+	Gold     = "80 PLUS Gold"
+	Platinum = "80 PLUS Platinum"
+	Titanium = "80 PLUS Titanium"
+)
+
+type PSU struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Wattage is the rated continuous output in watts.
+	Wattage    int64  `json:"wattage"`
+	Efficiency string `json:"efficiency"`
+	Modular    bool   `json:"modular"`
+}
@@ -1,14 +1,41 @@
 package ram
 
+import (
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+)
+
 const (
-	DDR3 = "DDR3"
-	DDR4 = "DDR4"
-	DDR5 = "DDR5"
+	DDR3    = "DDR3"
+	DDR4    = "DDR4"
+	DDR5    = "DDR5"
+	DDR4ECC = "DDR4 ECC"
+	DDR5ECC = "DDR5 ECC"
+	LPDDR4  = "LPDDR4"
+	LPDDR4X = "LPDDR4X"
+	LPDDR5  = "LPDDR5"
+	LPDDR5X = "LPDDR5X"
 )
 
+// Types lists the memory types accepted in RAM.MemoryType.
+var Types = []string{DDR3, DDR4, DDR5, DDR4ECC, DDR5ECC, LPDDR4, LPDDR4X, LPDDR5, LPDDR5X}
+
 type RAM struct {
-	ID         int64
-	Name       string
-	MemoryType string
-	Capacity   int64
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	MemoryType string `json:"memory_type"`
+	// Capacity is the total of the kit, split over Modules sticks.
+	Capacity units.Bytes `json:"capacity"`
+	// Speed is in MT/s.
+	Speed      int64 `json:"speed"`
+	CASLatency int64 `json:"cas_latency"`
+	Modules    int64 `json:"modules"`
+	ECC        bool  `json:"ecc"`
+}
+
+// Generation strips the ECC suffix from a memory type, ECC modules fit the
+// same slots as plain ones of their generation.
+func Generation(memoryType string) string {
+	return strings.TrimSuffix(memoryType, " ECC")
 }
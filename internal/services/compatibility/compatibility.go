@@ -0,0 +1,94 @@
+// Package compatibility checks whether the components of a build fit
+// together. Every check is a Rule, a Checker runs a set of them and groups
+// what they find into a Report.
+package compatibility
+
+import "github.com/r33ta/pc-database-manager/internal/models/pc"
+
+type Severity string
+
+const (
+	// SeverityError means the build cannot be assembled as is.
+	SeverityError Severity = "error"
+	// SeverityWarning means the build may work but something is off or
+	// could not be checked.
+	SeverityWarning Severity = "warning"
+)
+
+// Component points at a component involved in an issue. ID is zero for
+// components of a proposed build that are not saved yet.
+type Component struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id,omitempty"`
+}
+
+// Issue is a single problem found by a rule.
+type Issue struct {
+	Rule       string      `json:"rule"`
+	Severity   Severity    `json:"severity"`
+	Message    string      `json:"message"`
+	Components []Component `json:"components,omitempty"`
+}
+
+// Report holds the issues found in a build, split by severity. A build is
+// compatible when no rule reported an error.
+type Report struct {
+	Compatible bool    `json:"compatible"`
+	Errors     []Issue `json:"errors"`
+	Warnings   []Issue `json:"warnings"`
+}
+
+// Rule checks one aspect of a build. Check returns nil when the build is
+// fine or lacks the components the rule looks at, the components rule
+// reports missing ones.
+type Rule struct {
+	Name  string
+	Check func(b *pc.Expanded) []Issue
+}
+
+// DefaultRules is the rule set used by the api.
+var DefaultRules = []Rule{
+	{"components", Components},
+	{"socket", Socket},
+	{"memory_type", MemoryType},
+	{"ram_slots", RAMSlots},
+	{"ram_capacity", RAMCapacity},
+	{"form_factor", FormFactor},
+	{"cooler_height", CoolerHeight},
+	{"gpu_length", GPULength},
+	{"storage_ports", StoragePorts},
+}
+
+type Checker struct {
+	rules []Rule
+}
+
+func New(rules ...Rule) *Checker {
+	return &Checker{rules: rules}
+}
+
+// Check runs every rule against the build. Issues get the name of the rule
+// that reported them.
+func (c *Checker) Check(b *pc.Expanded) Report {
+	res := Report{
+		Compatible: true,
+		Errors:     []Issue{},
+		Warnings:   []Issue{},
+	}
+
+	for _, r := range c.rules {
+		for _, issue := range r.Check(b) {
+			issue.Rule = r.Name
+
+			switch issue.Severity {
+			case SeverityError:
+				res.Compatible = false
+				res.Errors = append(res.Errors, issue)
+			default:
+				res.Warnings = append(res.Warnings, issue)
+			}
+		}
+	}
+
+	return res
+}
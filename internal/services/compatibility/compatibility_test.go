@@ -0,0 +1,78 @@
+package compatibility
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+)
+
+func TestCheckGroupsIssues(t *testing.T) {
+	fails := Rule{"fails", func(*pc.Expanded) []Issue {
+		return []Issue{
+			{Severity: SeverityError, Message: "first"},
+			{Severity: SeverityWarning, Message: "second"},
+		}
+	}}
+	warns := Rule{"warns", func(*pc.Expanded) []Issue {
+		return []Issue{{Severity: SeverityWarning, Message: "third", Components: []Component{{"cpu", 1}}}}
+	}}
+	passes := Rule{"passes", func(*pc.Expanded) []Issue { return nil }}
+
+	got := New(passes, fails, warns).Check(build(nil))
+	want := Report{
+		Compatible: false,
+		Errors:     []Issue{{Rule: "fails", Severity: SeverityError, Message: "first"}},
+		Warnings: []Issue{
+			{Rule: "fails", Severity: SeverityWarning, Message: "second"},
+			{Rule: "warns", Severity: SeverityWarning, Message: "third", Components: []Component{{"cpu", 1}}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check() = %+v\nwant %+v", got, want)
+	}
+}
+
+func TestCheckWarningsKeepCompatible(t *testing.T) {
+	got := New(DefaultRules...).Check(build(func(b *pc.Expanded) { b.Cooler = nil }))
+
+	if !got.Compatible || len(got.Errors) != 0 {
+		t.Errorf("Check() = %+v, want compatible", got)
+	}
+	if len(got.Warnings) != 1 || got.Warnings[0].Rule != "components" {
+		t.Errorf("Check() warnings = %+v, want the missing cooler", got.Warnings)
+	}
+}
+
+func TestCheckDefaultRules(t *testing.T) {
+	b := build(func(b *pc.Expanded) {
+		b.CPU.Socket = "LGA1700"
+		b.Case.MaxGPULength = 200
+		b.PSU = nil
+	})
+
+	got := New(DefaultRules...).Check(b)
+	if got.Compatible {
+		t.Fatalf("Check() = %+v, want incompatible", got)
+	}
+
+	var rules []string
+	for _, issue := range got.Errors {
+		rules = append(rules, issue.Rule)
+	}
+	if want := []string{"socket", "gpu_length"}; !reflect.DeepEqual(rules, want) {
+		t.Errorf("Check() errors from %v, want %v", rules, want)
+	}
+	if len(got.Warnings) != 1 || got.Warnings[0].Message != "build has no psu" {
+		t.Errorf("Check() warnings = %+v, want the missing psu", got.Warnings)
+	}
+}
+
+func TestCheckEmptyReport(t *testing.T) {
+	got := New().Check(build(nil))
+
+	// empty lists rather than nil, the api renders them as []
+	if !got.Compatible || got.Errors == nil || got.Warnings == nil {
+		t.Errorf("Check() = %#v", got)
+	}
+}
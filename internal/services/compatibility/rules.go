@@ -0,0 +1,261 @@
+package compatibility
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
+// Components warns about optional components missing from the build, the
+// rules needing them are skipped.
+func Components(b *pc.Expanded) []Issue {
+	var res []Issue
+
+	if b.Motherboard == nil {
+		res = append(res, warning("build has no motherboard, socket, memory and storage checks are skipped"))
+	}
+	if b.Case == nil {
+		res = append(res, warning("build has no case, clearance checks are skipped"))
+	}
+	if b.PSU == nil {
+		res = append(res, warning("build has no psu"))
+	}
+	if b.Cooler == nil {
+		res = append(res, warning("build has no cooler"))
+	}
+
+	return res
+}
+
+// Socket reports a cpu that does not fit the motherboard socket. It is
+// skipped when either socket is not recorded.
+func Socket(b *pc.Expanded) []Issue {
+	if b.Motherboard == nil || b.CPU.Socket == "" || b.Motherboard.Socket == "" {
+		return nil
+	}
+	if strings.EqualFold(b.CPU.Socket, b.Motherboard.Socket) {
+		return nil
+	}
+
+	return []Issue{{
+		Severity: SeverityError,
+		Message: fmt.Sprintf("cpu %q is %s, motherboard %q is %s",
+			b.CPU.Name, b.CPU.Socket, b.Motherboard.Name, b.Motherboard.Socket),
+		Components: []Component{{"cpu", b.CPU.ID}, {"motherboard", b.Motherboard.ID}},
+	}}
+}
+
+// MemoryType reports ram modules of a different generation than the
+// motherboard supports.
+func MemoryType(b *pc.Expanded) []Issue {
+	if b.Motherboard == nil {
+		return nil
+	}
+
+	var res []Issue
+	for _, r := range b.RAM {
+		if strings.EqualFold(ram.Generation(r.MemoryType), ram.Generation(b.Motherboard.MemoryType)) {
+			continue
+		}
+		res = append(res, Issue{
+			Severity: SeverityError,
+			Message: fmt.Sprintf("ram %q is %s, motherboard %q supports %s",
+				r.Name, r.MemoryType, b.Motherboard.Name, b.Motherboard.MemoryType),
+			Components: []Component{{"ram", r.ID}, {"motherboard", b.Motherboard.ID}},
+		})
+	}
+
+	return res
+}
+
+// RAMSlots reports more ram modules than the motherboard has slots for.
+func RAMSlots(b *pc.Expanded) []Issue {
+	if b.Motherboard == nil || b.Motherboard.RAMSlots == 0 {
+		return nil
+	}
+
+	var modules int64
+	for _, r := range b.RAM {
+		modules += r.Modules * r.Quantity
+	}
+	if modules <= b.Motherboard.RAMSlots {
+		return nil
+	}
+
+	return []Issue{{
+		Severity: SeverityError,
+		Message: fmt.Sprintf("build has %d ram modules, motherboard %q has %d slots",
+			modules, b.Motherboard.Name, b.Motherboard.RAMSlots),
+		Components: []Component{{"motherboard", b.Motherboard.ID}},
+	}}
+}
+
+// RAMCapacity reports more ram in total than the motherboard supports.
+func RAMCapacity(b *pc.Expanded) []Issue {
+	if b.Motherboard == nil || b.Motherboard.MaxMemory == 0 {
+		return nil
+	}
+
+	var capacity units.Bytes
+	for _, r := range b.RAM {
+		capacity += r.Capacity * units.Bytes(r.Quantity)
+	}
+	if capacity <= b.Motherboard.MaxMemory {
+		return nil
+	}
+
+	return []Issue{{
+		Severity: SeverityError,
+		Message: fmt.Sprintf("build has %s of ram, motherboard %q supports up to %s",
+			capacity, b.Motherboard.Name, b.Motherboard.MaxMemory),
+		Components: []Component{{"motherboard", b.Motherboard.ID}},
+	}}
+}
+
+// formFactorSizes orders the motherboard form factors from the smallest.
+var formFactorSizes = map[string]int{
+	motherboard.MiniITX:  1,
+	motherboard.MicroATX: 2,
+	motherboard.ATX:      3,
+	motherboard.EATX:     4,
+}
+
+// FormFactor reports a motherboard larger than the case takes.
+func FormFactor(b *pc.Expanded) []Issue {
+	if b.Motherboard == nil || b.Case == nil {
+		return nil
+	}
+
+	board, ok := formFactorSizes[b.Motherboard.FormFactor]
+	if !ok {
+		return nil
+	}
+	fits, ok := formFactorSizes[b.Case.FormFactor]
+	if !ok || board <= fits {
+		return nil
+	}
+
+	return []Issue{{
+		Severity: SeverityError,
+		Message: fmt.Sprintf("motherboard %q is %s, case %q fits up to %s",
+			b.Motherboard.Name, b.Motherboard.FormFactor, b.Case.Name, b.Case.FormFactor),
+		Components: []Component{{"motherboard", b.Motherboard.ID}, {"case", b.Case.ID}},
+	}}
+}
+
+// CoolerHeight reports a cooler taller than the case clearance.
+func CoolerHeight(b *pc.Expanded) []Issue {
+	if b.Cooler == nil || b.Case == nil || b.Cooler.Height == 0 || b.Case.MaxCoolerHeight == 0 {
+		return nil
+	}
+	if b.Cooler.Height <= b.Case.MaxCoolerHeight {
+		return nil
+	}
+
+	return []Issue{{
+		Severity: SeverityError,
+		Message: fmt.Sprintf("cooler %q is %dmm tall, case %q fits up to %dmm",
+			b.Cooler.Name, b.Cooler.Height, b.Case.Name, b.Case.MaxCoolerHeight),
+		Components: []Component{{"cooler", b.Cooler.ID}, {"case", b.Case.ID}},
+	}}
+}
+
+// GPULength reports graphics cards longer than the case clearance.
+func GPULength(b *pc.Expanded) []Issue {
+	if b.Case == nil || b.Case.MaxGPULength == 0 {
+		return nil
+	}
+
+	var res []Issue
+	for _, g := range b.GPU {
+		if g.Length == 0 || g.Length <= b.Case.MaxGPULength {
+			continue
+		}
+		res = append(res, Issue{
+			Severity: SeverityError,
+			Message: fmt.Sprintf("gpu %q is %dmm long, case %q fits up to %dmm",
+				g.Name, g.Length, b.Case.Name, b.Case.MaxGPULength),
+			Components: []Component{{"gpu", g.ID}, {"case", b.Case.ID}},
+		})
+	}
+
+	return res
+}
+
+// StoragePorts reports more drives than the motherboard can connect. M.2
+// and nvme drives need an m.2 slot, hard drives, sata ssds and 2.5 or 3.5
+// inch drives need a sata port. Plain ssds take an m.2 slot while one is
+// free and a sata port otherwise. A motherboard listing neither is skipped.
+func StoragePorts(b *pc.Expanded) []Issue {
+	if b.Motherboard == nil || (b.Motherboard.M2Slots == 0 && b.Motherboard.SATAPorts == 0) {
+		return nil
+	}
+
+	var m2, sata, either int64
+	for _, m := range b.Memory {
+		switch drivePort(m.Memory) {
+		case m2Port:
+			m2 += m.Quantity
+		case sataPort:
+			sata += m.Quantity
+		default:
+			either += m.Quantity
+		}
+	}
+
+	var res []Issue
+	if m2 > b.Motherboard.M2Slots {
+		res = append(res, Issue{
+			Severity: SeverityError,
+			Message: fmt.Sprintf("build needs %d m.2 slots, motherboard %q has %d",
+				m2, b.Motherboard.Name, b.Motherboard.M2Slots),
+			Components: []Component{{"motherboard", b.Motherboard.ID}},
+		})
+	}
+
+	sata += max(either-max(b.Motherboard.M2Slots-m2, 0), 0)
+	if sata > b.Motherboard.SATAPorts {
+		res = append(res, Issue{
+			Severity: SeverityError,
+			Message: fmt.Sprintf("build needs %d sata ports, motherboard %q has %d sata ports and %d m.2 slots",
+				sata, b.Motherboard.Name, b.Motherboard.SATAPorts, b.Motherboard.M2Slots),
+			Components: []Component{{"motherboard", b.Motherboard.ID}},
+		})
+	}
+
+	return res
+}
+
+type port int
+
+const (
+	eitherPort port = iota
+	m2Port
+	sataPort
+)
+
+// drivePort tells which connector a drive takes. The form factor wins over
+// the type, an m.2 sata ssd still takes an m.2 slot.
+func drivePort(m memory.Memory) port {
+	switch {
+	case m.IsM2():
+		return m2Port
+	case m.FormFactor == memory.Inch25 || m.FormFactor == memory.Inch35:
+		return sataPort
+	case strings.EqualFold(m.StorageType, memory.NVMe) || strings.HasPrefix(m.Interface, "PCIe"):
+		return m2Port
+	case strings.EqualFold(m.StorageType, memory.HDD) || strings.EqualFold(m.StorageType, memory.SATASSD) || m.Interface == memory.SATA:
+		return sataPort
+	}
+
+	return eitherPort
+}
+
+func warning(msg string) Issue {
+	return Issue{Severity: SeverityWarning, Message: msg}
+}
@@ -0,0 +1,293 @@
+package compatibility
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
+// build returns a build every rule accepts, changed by edit.
+func build(edit func(b *pc.Expanded)) *pc.Expanded {
+	b := &pc.Expanded{
+		ID:   1,
+		Name: "Workstation",
+		CPU:  cpu.CPU{ID: 2, Name: "Ryzen 7 7700", Socket: "AM5"},
+		RAM: []pc.RAMPart{{
+			RAM:      ram.RAM{ID: 3, Name: "Vengeance", MemoryType: ram.DDR5, Capacity: 32 * units.GiB, Modules: 2},
+			Quantity: 1,
+		}},
+		GPU: []pc.GPUPart{{
+			GPU:      gpu.GPU{ID: 4, Name: "RTX 4070", Length: 267},
+			Quantity: 1,
+		}},
+		Memory: []pc.MemoryPart{{
+			Memory:   memory.Memory{ID: 5, Name: "990 Pro", StorageType: memory.NVMe, FormFactor: memory.M2Size2280},
+			Quantity: 1,
+		}},
+		Motherboard: &motherboard.Motherboard{
+			ID: 6, Name: "B650 Tomahawk", Socket: "AM5", FormFactor: motherboard.ATX,
+			MemoryType: ram.DDR5, RAMSlots: 4, MaxMemory: 128 * units.GiB, M2Slots: 2, SATAPorts: 4,
+		},
+		PSU:    &psu.PSU{ID: 7, Name: "RM850x", Wattage: 850},
+		Case:   &pccase.Case{ID: 8, Name: "Meshify 2", FormFactor: motherboard.EATX, MaxGPULength: 360, MaxCoolerHeight: 170},
+		Cooler: &cooler.Cooler{ID: 9, Name: "NH-D15", Type: cooler.Air, Height: 165},
+	}
+	if edit != nil {
+		edit(b)
+	}
+
+	return b
+}
+
+type ruleTest struct {
+	name string
+	edit func(b *pc.Expanded)
+	want []Issue
+}
+
+func testRule(t *testing.T, rule func(b *pc.Expanded) []Issue, tests []ruleTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rule(build(tt.edit))
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComponents(t *testing.T) {
+	testRule(t, Components, []ruleTest{
+		{name: "complete"},
+		{
+			name: "no psu and cooler",
+			edit: func(b *pc.Expanded) { b.PSU, b.Cooler = nil, nil },
+			want: []Issue{warning("build has no psu"), warning("build has no cooler")},
+		},
+		{
+			name: "no motherboard and case",
+			edit: func(b *pc.Expanded) { b.Motherboard, b.Case = nil, nil },
+			want: []Issue{
+				warning("build has no motherboard, socket, memory and storage checks are skipped"),
+				warning("build has no case, clearance checks are skipped"),
+			},
+		},
+	})
+}
+
+func TestSocket(t *testing.T) {
+	testRule(t, Socket, []ruleTest{
+		{name: "same socket"},
+		{name: "case differs", edit: func(b *pc.Expanded) { b.CPU.Socket = "am5" }},
+		{
+			name: "different socket",
+			edit: func(b *pc.Expanded) { b.CPU.Socket = "LGA1700" },
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `cpu "Ryzen 7 7700" is LGA1700, motherboard "B650 Tomahawk" is AM5`,
+				Components: []Component{{"cpu", 2}, {"motherboard", 6}},
+			}},
+		},
+		{name: "cpu socket unknown", edit: func(b *pc.Expanded) { b.CPU.Socket = "" }},
+		{name: "motherboard socket unknown", edit: func(b *pc.Expanded) { b.Motherboard.Socket = "" }},
+		{name: "no motherboard", edit: func(b *pc.Expanded) { b.Motherboard = nil }},
+	})
+}
+
+func TestMemoryType(t *testing.T) {
+	testRule(t, MemoryType, []ruleTest{
+		{name: "same generation"},
+		{name: "ecc of the generation", edit: func(b *pc.Expanded) { b.RAM[0].MemoryType = ram.DDR5ECC }},
+		{name: "ecc board", edit: func(b *pc.Expanded) { b.Motherboard.MemoryType = ram.DDR5ECC }},
+		{
+			name: "other generation",
+			edit: func(b *pc.Expanded) {
+				b.RAM = append(b.RAM, pc.RAMPart{RAM: ram.RAM{ID: 10, Name: "Fury", MemoryType: ram.DDR4}, Quantity: 1})
+			},
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `ram "Fury" is DDR4, motherboard "B650 Tomahawk" supports DDR5`,
+				Components: []Component{{"ram", 10}, {"motherboard", 6}},
+			}},
+		},
+		{name: "no ram", edit: func(b *pc.Expanded) { b.RAM = nil }},
+		{name: "no motherboard", edit: func(b *pc.Expanded) { b.Motherboard = nil }},
+	})
+}
+
+func TestRAMSlots(t *testing.T) {
+	testRule(t, RAMSlots, []ruleTest{
+		{name: "free slots"},
+		{name: "every slot", edit: func(b *pc.Expanded) { b.RAM[0].Quantity = 2 }},
+		{
+			name: "too many modules",
+			edit: func(b *pc.Expanded) { b.RAM[0].Quantity = 3 },
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `build has 6 ram modules, motherboard "B650 Tomahawk" has 4 slots`,
+				Components: []Component{{"motherboard", 6}},
+			}},
+		},
+		{name: "slots unknown", edit: func(b *pc.Expanded) { b.RAM[0].Quantity = 3; b.Motherboard.RAMSlots = 0 }},
+		{name: "no motherboard", edit: func(b *pc.Expanded) { b.Motherboard = nil }},
+	})
+}
+
+func TestRAMCapacity(t *testing.T) {
+	testRule(t, RAMCapacity, []ruleTest{
+		{name: "below the limit"},
+		{name: "at the limit", edit: func(b *pc.Expanded) { b.RAM[0].Quantity = 4 }},
+		{
+			name: "over the limit",
+			edit: func(b *pc.Expanded) { b.RAM[0].Capacity = 96 * units.GiB; b.RAM[0].Quantity = 2 },
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `build has 192 GiB of ram, motherboard "B650 Tomahawk" supports up to 128 GiB`,
+				Components: []Component{{"motherboard", 6}},
+			}},
+		},
+		{name: "limit unknown", edit: func(b *pc.Expanded) { b.RAM[0].Quantity = 8; b.Motherboard.MaxMemory = 0 }},
+		{name: "no motherboard", edit: func(b *pc.Expanded) { b.Motherboard = nil }},
+	})
+}
+
+func TestFormFactor(t *testing.T) {
+	testRule(t, FormFactor, []ruleTest{
+		{name: "smaller board"},
+		{name: "same size", edit: func(b *pc.Expanded) { b.Case.FormFactor = motherboard.ATX }},
+		{
+			name: "board too large",
+			edit: func(b *pc.Expanded) { b.Case.FormFactor = motherboard.MicroATX },
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `motherboard "B650 Tomahawk" is ATX, case "Meshify 2" fits up to Micro-ATX`,
+				Components: []Component{{"motherboard", 6}, {"case", 8}},
+			}},
+		},
+		{name: "board form factor unknown", edit: func(b *pc.Expanded) { b.Case.FormFactor = motherboard.MiniITX; b.Motherboard.FormFactor = "" }},
+		{name: "case form factor unknown", edit: func(b *pc.Expanded) { b.Case.FormFactor = "" }},
+		{name: "no case", edit: func(b *pc.Expanded) { b.Case = nil }},
+		{name: "no motherboard", edit: func(b *pc.Expanded) { b.Motherboard = nil }},
+	})
+}
+
+func TestCoolerHeight(t *testing.T) {
+	testRule(t, CoolerHeight, []ruleTest{
+		{name: "fits"},
+		{name: "exact fit", edit: func(b *pc.Expanded) { b.Cooler.Height = 170 }},
+		{
+			name: "too tall",
+			edit: func(b *pc.Expanded) { b.Case.MaxCoolerHeight = 155 },
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `cooler "NH-D15" is 165mm tall, case "Meshify 2" fits up to 155mm`,
+				Components: []Component{{"cooler", 9}, {"case", 8}},
+			}},
+		},
+		{name: "height unknown", edit: func(b *pc.Expanded) { b.Case.MaxCoolerHeight = 155; b.Cooler.Height = 0 }},
+		{name: "clearance unknown", edit: func(b *pc.Expanded) { b.Case.MaxCoolerHeight = 0 }},
+		{name: "no cooler", edit: func(b *pc.Expanded) { b.Cooler = nil }},
+		{name: "no case", edit: func(b *pc.Expanded) { b.Case = nil }},
+	})
+}
+
+func TestGPULength(t *testing.T) {
+	testRule(t, GPULength, []ruleTest{
+		{name: "fits"},
+		{name: "exact fit", edit: func(b *pc.Expanded) { b.GPU[0].Length = 360 }},
+		{
+			name: "too long",
+			edit: func(b *pc.Expanded) {
+				b.GPU = append(b.GPU, pc.GPUPart{GPU: gpu.GPU{ID: 11, Name: "RTX 4090", Length: 380}, Quantity: 1})
+			},
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `gpu "RTX 4090" is 380mm long, case "Meshify 2" fits up to 360mm`,
+				Components: []Component{{"gpu", 11}, {"case", 8}},
+			}},
+		},
+		{name: "length unknown", edit: func(b *pc.Expanded) { b.Case.MaxGPULength = 200; b.GPU[0].Length = 0 }},
+		{name: "clearance unknown", edit: func(b *pc.Expanded) { b.Case.MaxGPULength = 0 }},
+		{name: "no case", edit: func(b *pc.Expanded) { b.Case = nil }},
+	})
+}
+
+func TestStoragePorts(t *testing.T) {
+	drive := func(storageType, iface, formFactor string, quantity int64) pc.MemoryPart {
+		return pc.MemoryPart{
+			Memory:   memory.Memory{StorageType: storageType, Interface: iface, FormFactor: formFactor},
+			Quantity: quantity,
+		}
+	}
+
+	testRule(t, StoragePorts, []ruleTest{
+		{name: "free slots"},
+		{
+			name: "every port",
+			edit: func(b *pc.Expanded) {
+				b.Memory = []pc.MemoryPart{
+					drive(memory.NVMe, memory.PCIe4, memory.M2Size2280, 1),
+					drive(memory.SATASSD, memory.SATA, memory.M2Size2280, 1),
+					drive(memory.HDD, memory.SATA, memory.Inch35, 2),
+					drive(memory.SSD, "", "", 2),
+				}
+			},
+		},
+		{
+			name: "plain ssds take free m.2 slots first",
+			edit: func(b *pc.Expanded) {
+				b.Memory = []pc.MemoryPart{drive(memory.SSD, "", "", 6)}
+			},
+		},
+		{
+			name: "too many m.2 drives",
+			edit: func(b *pc.Expanded) {
+				b.Memory = []pc.MemoryPart{drive(memory.NVMe, "", "", 2), drive(memory.SATASSD, "", memory.M2Size2242, 1)}
+			},
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `build needs 3 m.2 slots, motherboard "B650 Tomahawk" has 2`,
+				Components: []Component{{"motherboard", 6}},
+			}},
+		},
+		{
+			name: "too many sata drives",
+			edit: func(b *pc.Expanded) {
+				b.Memory = []pc.MemoryPart{
+					drive(memory.NVMe, memory.PCIe4, memory.M2Size2280, 2),
+					drive(memory.HDD, "", "", 3),
+					drive(memory.SSD, "", memory.Inch25, 1),
+					drive(memory.SSD, "", "", 1),
+				}
+			},
+			want: []Issue{{
+				Severity:   SeverityError,
+				Message:    `build needs 5 sata ports, motherboard "B650 Tomahawk" has 4 sata ports and 2 m.2 slots`,
+				Components: []Component{{"motherboard", 6}},
+			}},
+		},
+		{
+			name: "ports unknown",
+			edit: func(b *pc.Expanded) {
+				b.Memory[0].Quantity = 10
+				b.Motherboard.M2Slots, b.Motherboard.SATAPorts = 0, 0
+			},
+		},
+		{name: "no motherboard", edit: func(b *pc.Expanded) { b.Memory[0].Quantity = 10; b.Motherboard = nil }},
+	})
+}
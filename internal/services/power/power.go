@@ -0,0 +1,101 @@
+// Package power estimates how much a build draws from the wall and whether
+// its psu can supply it.
+package power
+
+import (
+	"math"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+)
+
+// Typical draw in watts of the components that do not record their own.
+// Budget uses them as is, they are assumptions and never show in Unknown.
+const (
+	MotherboardDraw  = 50
+	RAMModuleDraw    = 5
+	SSDDraw          = 5
+	HDDDraw          = 10
+	AirCoolerDraw    = 5
+	LiquidCoolerDraw = 15
+)
+
+// Budget is the estimated draw of a build in watts, broken down by
+// component kind. CPU and GPU are the recorded draws of the parts, RAM,
+// Storage, Motherboard and Cooler the assumed typical draws above.
+type Budget struct {
+	CPU         int64 `json:"cpu"`
+	GPU         int64 `json:"gpu"`
+	RAM         int64 `json:"ram"`
+	Storage     int64 `json:"storage"`
+	Motherboard int64 `json:"motherboard"`
+	Cooler      int64 `json:"cooler"`
+	Total       int64 `json:"total"`
+
+	// Headroom is the share added to Total to get Recommended.
+	Headroom    float64 `json:"headroom"`
+	Recommended int64   `json:"recommended"`
+
+	// PSUWattage is zero and NoPSU set when the build has no psu, then
+	// nothing powers the build and UnderProvisioned is set as well.
+	// Otherwise UnderProvisioned is set when the psu is rated below
+	// Recommended.
+	PSUWattage       int64 `json:"psu_wattage,omitempty"`
+	NoPSU            bool  `json:"no_psu"`
+	UnderProvisioned bool  `json:"under_provisioned"`
+
+	// Unknown lists components whose draw is not recorded, the estimate is
+	// too low by their draw.
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// Estimate sums the draw of every component of the build and compares it,
+// plus headroom, to the psu rating.
+func Estimate(b *pc.Expanded, headroom float64) Budget {
+	res := Budget{Headroom: headroom}
+
+	res.CPU = b.CPU.TDP
+	if b.CPU.TDP == 0 {
+		res.Unknown = append(res.Unknown, "cpu "+b.CPU.Name)
+	}
+	for _, g := range b.GPU {
+		res.GPU += g.BoardPower * g.Quantity
+		if g.BoardPower == 0 {
+			res.Unknown = append(res.Unknown, "gpu "+g.Name)
+		}
+	}
+	for _, r := range b.RAM {
+		res.RAM += RAMModuleDraw * r.Modules * r.Quantity
+	}
+	for _, m := range b.Memory {
+		if strings.EqualFold(m.StorageType, memory.HDD) {
+			res.Storage += HDDDraw * m.Quantity
+		} else {
+			res.Storage += SSDDraw * m.Quantity
+		}
+	}
+	if b.Motherboard != nil {
+		res.Motherboard = MotherboardDraw
+	}
+	if b.Cooler != nil {
+		res.Cooler = AirCoolerDraw
+		if b.Cooler.Type == cooler.Liquid {
+			res.Cooler = LiquidCoolerDraw
+		}
+	}
+
+	res.Total = res.CPU + res.GPU + res.RAM + res.Storage + res.Motherboard + res.Cooler
+	res.Recommended = int64(math.Ceil(float64(res.Total) * (1 + headroom)))
+
+	if b.PSU == nil {
+		res.NoPSU = true
+		res.UnderProvisioned = true
+	} else {
+		res.PSUWattage = b.PSU.Wattage
+		res.UnderProvisioned = b.PSU.Wattage < res.Recommended
+	}
+
+	return res
+}
@@ -0,0 +1,151 @@
+package power
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
+// build returns a build drawing 335W on an 850W psu, changed by edit.
+func build(edit func(b *pc.Expanded)) *pc.Expanded {
+	b := &pc.Expanded{
+		CPU: cpu.CPU{Name: "Ryzen 7 7700", TDP: 65},
+		RAM: []pc.RAMPart{{
+			RAM:      ram.RAM{Name: "Vengeance", Modules: 2},
+			Quantity: 1,
+		}},
+		GPU: []pc.GPUPart{{
+			GPU:      gpu.GPU{Name: "RTX 4070", BoardPower: 200},
+			Quantity: 1,
+		}},
+		Memory: []pc.MemoryPart{{
+			Memory:   memory.Memory{Name: "990 Pro", StorageType: memory.NVMe},
+			Quantity: 1,
+		}},
+		Motherboard: &motherboard.Motherboard{Name: "B650 Tomahawk"},
+		PSU:         &psu.PSU{Name: "RM850x", Wattage: 850},
+		Cooler:      &cooler.Cooler{Name: "NH-D15", Type: cooler.Air},
+	}
+	if edit != nil {
+		edit(b)
+	}
+
+	return b
+}
+
+// budget returns the estimate of build(nil) with 30% headroom, changed by
+// edit.
+func budget(edit func(b *Budget)) Budget {
+	b := Budget{
+		CPU:         65,
+		GPU:         200,
+		RAM:         10,
+		Storage:     5,
+		Motherboard: 50,
+		Cooler:      5,
+		Total:       335,
+		Headroom:    0.3,
+		Recommended: 436,
+		PSUWattage:  850,
+	}
+	if edit != nil {
+		edit(&b)
+	}
+
+	return b
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(b *pc.Expanded)
+		headroom float64
+		want     Budget
+	}{
+		{
+			name:     "provisioned",
+			headroom: 0.3,
+			want:     budget(nil),
+		},
+		{
+			name: "quantities",
+			edit: func(b *pc.Expanded) {
+				b.GPU[0].Quantity = 2
+				b.RAM[0].Quantity = 2
+				b.Memory = append(b.Memory, pc.MemoryPart{Memory: memory.Memory{StorageType: "hdd"}, Quantity: 2})
+				b.Cooler.Type = cooler.Liquid
+			},
+			headroom: 0.3,
+			want: budget(func(b *Budget) {
+				b.GPU, b.RAM, b.Storage, b.Cooler = 400, 20, 25, 15
+				b.Total, b.Recommended = 575, 748
+			}),
+		},
+		{
+			name:     "no headroom",
+			headroom: 0,
+			want:     budget(func(b *Budget) { b.Headroom, b.Recommended = 0, 335 }),
+		},
+		{
+			name:     "recommended rounds up",
+			headroom: 0.25,
+			want:     budget(func(b *Budget) { b.Headroom, b.Recommended = 0.25, 419 }),
+		},
+		{
+			name:     "psu below recommended",
+			edit:     func(b *pc.Expanded) { b.PSU.Wattage = 435 },
+			headroom: 0.3,
+			want:     budget(func(b *Budget) { b.PSUWattage, b.UnderProvisioned = 435, true }),
+		},
+		{
+			name:     "psu at recommended",
+			edit:     func(b *pc.Expanded) { b.PSU.Wattage = 436 },
+			headroom: 0.3,
+			want:     budget(func(b *Budget) { b.PSUWattage = 436 }),
+		},
+		{
+			name:     "no psu",
+			edit:     func(b *pc.Expanded) { b.PSU = nil },
+			headroom: 0.3,
+			want:     budget(func(b *Budget) { b.PSUWattage, b.NoPSU, b.UnderProvisioned = 0, true, true }),
+		},
+		{
+			name: "draw not recorded",
+			edit: func(b *pc.Expanded) {
+				b.CPU.TDP = 0
+				b.GPU[0].BoardPower = 0
+			},
+			headroom: 0.3,
+			want: budget(func(b *Budget) {
+				b.CPU, b.GPU, b.Total, b.Recommended = 0, 0, 70, 91
+				b.Unknown = []string{"cpu Ryzen 7 7700", "gpu RTX 4070"}
+			}),
+		},
+		{
+			name: "no motherboard and cooler",
+			edit: func(b *pc.Expanded) {
+				b.Motherboard, b.Cooler = nil, nil
+			},
+			headroom: 0.3,
+			want: budget(func(b *Budget) {
+				b.Motherboard, b.Cooler, b.Total, b.Recommended = 0, 0, 280, 364
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Estimate(build(tt.edit), tt.headroom); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Estimate() = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
+// BuildTx saves components within one transaction, SQL backends implement
+// it on top of theirs for SaveBuild.
+type BuildTx interface {
+	SaveCPU(ctx context.Context, c cpu.CPU) (int64, error)
+	SaveRAM(ctx context.Context, r ram.RAM) (int64, error)
+	SaveGPU(ctx context.Context, g gpu.GPU) (int64, error)
+	SaveMemory(ctx context.Context, m memory.Memory) (int64, error)
+	SaveMotherboard(ctx context.Context, m motherboard.Motherboard) (int64, error)
+	SavePSU(ctx context.Context, p psu.PSU) (int64, error)
+	SaveCase(ctx context.Context, c pccase.Case) (int64, error)
+	SaveCooler(ctx context.Context, c cooler.Cooler) (int64, error)
+	SavePC(ctx context.Context, p pc.PC) (int64, error)
+}
+
+// SaveBuild creates the new components of the build and the pc itself
+// through tx. A new component matching an existing one by its natural key,
+// also one listed earlier in the same build, reuses the existing row as it
+// is. The caller commits tx, nothing is left behind if it rolls back on an
+// error.
+func SaveBuild(ctx context.Context, tx BuildTx, b pc.Build) (pc.PC, error) {
+	res := pc.PC{Name: b.Name, CPUID: b.CPU.ID}
+	var err error
+
+	if res.CPUID == 0 {
+		id, err := tx.SaveCPU(ctx, b.CPU)
+		if res.CPUID, err = existing(id, err, ErrCPUAlreadyExists); err != nil {
+			return pc.PC{}, err
+		}
+	}
+	for _, r := range b.RAM {
+		if r.ID == 0 {
+			id, err := tx.SaveRAM(ctx, r.RAM)
+			if r.ID, err = existing(id, err, ErrRAMAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+		res.RAM = append(res.RAM, pc.Part{ID: r.ID, Quantity: r.Quantity, Slot: r.Slot})
+	}
+	for _, g := range b.GPU {
+		if g.ID == 0 {
+			id, err := tx.SaveGPU(ctx, g.GPU)
+			if g.ID, err = existing(id, err, ErrGPUAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+		res.GPU = append(res.GPU, pc.Part{ID: g.ID, Quantity: g.Quantity, Slot: g.Slot})
+	}
+	for _, m := range b.Memory {
+		if m.ID == 0 {
+			id, err := tx.SaveMemory(ctx, m.Memory)
+			if m.ID, err = existing(id, err, ErrMemoryAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+		res.Memory = append(res.Memory, pc.Part{ID: m.ID, Quantity: m.Quantity, Slot: m.Slot})
+	}
+	if b.Motherboard != nil {
+		res.MotherboardID = b.Motherboard.ID
+		if res.MotherboardID == 0 {
+			id, err := tx.SaveMotherboard(ctx, *b.Motherboard)
+			if res.MotherboardID, err = existing(id, err, ErrMotherboardAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+	}
+	if b.PSU != nil {
+		res.PSUID = b.PSU.ID
+		if res.PSUID == 0 {
+			id, err := tx.SavePSU(ctx, *b.PSU)
+			if res.PSUID, err = existing(id, err, ErrPSUAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+	}
+	if b.Case != nil {
+		res.CaseID = b.Case.ID
+		if res.CaseID == 0 {
+			id, err := tx.SaveCase(ctx, *b.Case)
+			if res.CaseID, err = existing(id, err, ErrCaseAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+	}
+	if b.Cooler != nil {
+		res.CoolerID = b.Cooler.ID
+		if res.CoolerID == 0 {
+			id, err := tx.SaveCooler(ctx, *b.Cooler)
+			if res.CoolerID, err = existing(id, err, ErrCoolerAlreadyExists); err != nil {
+				return pc.PC{}, err
+			}
+		}
+	}
+
+	if res.ID, err = tx.SavePC(ctx, res); err != nil {
+		return pc.PC{}, err
+	}
+
+	return res, nil
+}
+
+// existing turns errExists from a save into the id of the existing row the
+// save returned with it.
+func existing(id int64, err, errExists error) (int64, error) {
+	if err != nil && !errors.Is(err, errExists) {
+		return 0, err
+	}
+
+	return id, nil
+}
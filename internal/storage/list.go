@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort field")
+)
+
+// ListOptions controls pagination and ordering of List* results.
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	SortBy string
+	Desc   bool
+}
+
+// PageLimit returns the effective page size for the options.
+func (o ListOptions) PageLimit() int {
+	switch {
+	case o.Limit <= 0:
+		return DefaultListLimit
+	case o.Limit > MaxListLimit:
+		return MaxListLimit
+	default:
+		return o.Limit
+	}
+}
+
+// Int64Filter matches an integer field against an exact value and/or bounds.
+// Nil fields are ignored.
+type Int64Filter struct {
+	Eq  *int64
+	GTE *int64
+	LTE *int64
+}
+
+// PCFilter matches pcs by name and by the components they contain. A pc
+// matches a RAMID, GPUID, MemoryID or PeripheralID if any of its parts has
+// that id.
+type PCFilter struct {
+	Name     string
+	RAMID    *int64
+	CPUID    *int64
+	GPUID    *int64
+	MemoryID *int64
+
+	MotherboardID *int64
+	PSUID         *int64
+	CaseID        *int64
+	CoolerID      *int64
+
+	PeripheralID *int64
+}
+
+type RAMFilter struct {
+	Name       string
+	MemoryType string
+	Capacity   Int64Filter
+	Speed      Int64Filter
+}
+
+type CPUFilter struct {
+	Name      string
+	Vendor    string
+	Socket    string
+	Cores     Int64Filter
+	Threads   Int64Filter
+	BaseClock Int64Filter
+	TDP       Int64Filter
+}
+
+type GPUFilter struct {
+	Name         string
+	ChipVendor   string
+	Manufacturer string
+	MemoryType   string
+	Memory       Int64Filter
+	Frequency    Int64Filter
+	Length       Int64Filter
+}
+
+type MemoryFilter struct {
+	Name        string
+	StorageType string
+	Interface   string
+	FormFactor  string
+	Capacity    Int64Filter
+	ReadSpeed   Int64Filter
+}
+
+type PeripheralFilter struct {
+	Name         string
+	Type         string
+	Manufacturer string
+	Connection   string
+}
+
+// Cursor points right after the last row of a page: the value of the sort
+// field and the id of that row.
+type Cursor struct {
+	Value any   `json:"v"`
+	ID    int64 `json:"id"`
+}
+
+func EncodeCursor(c Cursor) string {
+	if b, ok := c.Value.([]byte); ok {
+		c.Value = string(b)
+	}
+
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var c Cursor
+	if err := dec.Decode(&c); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	// sort fields are either text or integers
+	if n, ok := c.Value.(json.Number); ok {
+		v, err := n.Int64()
+		if err != nil {
+			return Cursor{}, ErrInvalidCursor
+		}
+		c.Value = v
+	}
+
+	return c, nil
+}
@@ -0,0 +1,43 @@
+package memstore
+
+import (
+	"context"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+func (s *Storage) SaveCooler(_ context.Context, c cooler.Cooler) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findCooler(c); ok {
+		return id, storage.ErrCoolerAlreadyExists
+	}
+
+	c.ID = s.nextID("cooler")
+	s.coolers[c.ID] = c
+
+	return c.ID, nil
+}
+
+func (s *Storage) GetCooler(_ context.Context, id int64) (*cooler.Cooler, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.coolers[id]
+	if !ok {
+		return nil, storage.ErrCoolerNotFound
+	}
+
+	return &c, nil
+}
+
+func (s *Storage) findCooler(c cooler.Cooler) (int64, bool) {
+	for id, e := range s.coolers {
+		if e.Name == c.Name {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,308 @@
+package memstore
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// sortKey returns the value of a sortable field and whether the field exists.
+type sortKey[T any] func(item T, field string) (any, bool)
+
+// list filters, orders and paginates items the same way the SQL backends do:
+// by the sort field, then by id, starting right after the cursor.
+func list[T any](items map[int64]T, match func(T) bool, key sortKey[T], opts storage.ListOptions) ([]T, string, error) {
+	field := opts.SortBy
+	if field == "" {
+		field = "id"
+	}
+
+	var zero T
+	if _, ok := key(zero, field); !ok {
+		return nil, "", storage.ErrInvalidSort
+	}
+
+	type entry struct {
+		id    int64
+		value any
+		item  T
+	}
+
+	var after *storage.Cursor
+	if opts.Cursor != "" {
+		c, err := storage.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		after = &c
+	}
+
+	compareEntries := func(a, b entry) int {
+		if c := compareValues(a.value, b.value); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.id, b.id)
+	}
+	if opts.Desc {
+		asc := compareEntries
+		compareEntries = func(a, b entry) int { return asc(b, a) }
+	}
+
+	var entries []entry
+	for id, item := range items {
+		if !match(item) {
+			continue
+		}
+
+		value, _ := key(item, field)
+		e := entry{id: id, value: value, item: item}
+		if after != nil && compareEntries(e, entry{id: after.ID, value: after.Value}) <= 0 {
+			continue
+		}
+		entries = append(entries, e)
+	}
+
+	slices.SortFunc(entries, compareEntries)
+
+	limit := opts.PageLimit()
+	var next string
+	if len(entries) > limit {
+		entries = entries[:limit]
+		last := entries[limit-1]
+		next = storage.EncodeCursor(storage.Cursor{Value: last.value, ID: last.id})
+	}
+
+	res := make([]T, 0, len(entries))
+	for _, e := range entries {
+		res = append(res, e.item)
+	}
+
+	return res, next, nil
+}
+
+// compareValues orders int64 and string sort values. Values of different
+// types, which only come from forged cursors, compare as equal.
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			return cmp.Compare(a, b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b)
+		}
+	}
+	return 0
+}
+
+func matchInt64(v int64, f storage.Int64Filter) bool {
+	if f.Eq != nil && v != *f.Eq {
+		return false
+	}
+	if f.GTE != nil && v < *f.GTE {
+		return false
+	}
+	if f.LTE != nil && v > *f.LTE {
+		return false
+	}
+	return true
+}
+
+func matchID(v int64, want *int64) bool {
+	return want == nil || v == *want
+}
+
+func matchPart(parts []pc.Part, want *int64) bool {
+	return want == nil || hasPart(parts, *want)
+}
+
+func matchFold(v, want string) bool {
+	return want == "" || strings.EqualFold(v, want)
+}
+
+func (s *Storage) ListPC(_ context.Context, filter storage.PCFilter, opts storage.ListOptions) ([]pc.PC, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return list(s.pcs, func(p pc.PC) bool {
+		return containsFold(p.Name, filter.Name) &&
+			matchID(p.CPUID, filter.CPUID) &&
+			matchPart(p.RAM, filter.RAMID) &&
+			matchPart(p.GPU, filter.GPUID) &&
+			matchPart(p.Memory, filter.MemoryID) &&
+			matchID(p.MotherboardID, filter.MotherboardID) &&
+			matchID(p.PSUID, filter.PSUID) &&
+			matchID(p.CaseID, filter.CaseID) &&
+			matchID(p.CoolerID, filter.CoolerID) &&
+			matchPart(p.Peripherals, filter.PeripheralID)
+	}, func(p pc.PC, field string) (any, bool) {
+		switch field {
+		case "id":
+			return p.ID, true
+		case "name":
+			return p.Name, true
+		}
+		return nil, false
+	}, opts)
+}
+
+func (s *Storage) ListRAM(_ context.Context, filter storage.RAMFilter, opts storage.ListOptions) ([]ram.RAM, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return list(s.rams, func(r ram.RAM) bool {
+		return containsFold(r.Name, filter.Name) &&
+			matchFold(r.MemoryType, filter.MemoryType) &&
+			matchInt64(int64(r.Capacity), filter.Capacity) &&
+			matchInt64(r.Speed, filter.Speed)
+	}, func(r ram.RAM, field string) (any, bool) {
+		switch field {
+		case "id":
+			return r.ID, true
+		case "name":
+			return r.Name, true
+		case "memory_type":
+			return r.MemoryType, true
+		case "capacity":
+			return int64(r.Capacity), true
+		case "speed":
+			return r.Speed, true
+		}
+		return nil, false
+	}, opts)
+}
+
+func (s *Storage) ListCPU(_ context.Context, filter storage.CPUFilter, opts storage.ListOptions) ([]cpu.CPU, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return list(s.cpus, func(c cpu.CPU) bool {
+		return containsFold(c.Name, filter.Name) &&
+			matchInt64(c.Cores, filter.Cores) &&
+			matchInt64(c.Threads, filter.Threads) &&
+			matchInt64(int64(c.BaseClock), filter.BaseClock) &&
+			matchInt64(c.TDP, filter.TDP) &&
+			matchFold(c.Vendor, filter.Vendor) &&
+			matchFold(c.Socket, filter.Socket)
+	}, func(c cpu.CPU, field string) (any, bool) {
+		switch field {
+		case "id":
+			return c.ID, true
+		case "name":
+			return c.Name, true
+		case "cores":
+			return c.Cores, true
+		case "threads":
+			return c.Threads, true
+		case "base_clock":
+			return int64(c.BaseClock), true
+		case "boost_clock":
+			return int64(c.BoostClock), true
+		case "tdp":
+			return c.TDP, true
+		}
+		return nil, false
+	}, opts)
+}
+
+func (s *Storage) ListGPU(_ context.Context, filter storage.GPUFilter, opts storage.ListOptions) ([]gpu.GPU, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return list(s.gpus, func(g gpu.GPU) bool {
+		return containsFold(g.Name, filter.Name) &&
+			matchFold(g.ChipVendor, filter.ChipVendor) &&
+			matchFold(g.Manufacturer, filter.Manufacturer) &&
+			matchFold(g.MemoryType, filter.MemoryType) &&
+			matchInt64(int64(g.Memory), filter.Memory) &&
+			matchInt64(int64(g.Frequency), filter.Frequency) &&
+			matchInt64(g.Length, filter.Length)
+	}, func(g gpu.GPU, field string) (any, bool) {
+		switch field {
+		case "id":
+			return g.ID, true
+		case "name":
+			return g.Name, true
+		case "manufacturer":
+			return g.Manufacturer, true
+		case "memory":
+			return int64(g.Memory), true
+		case "frequency":
+			return int64(g.Frequency), true
+		case "board_power":
+			return g.BoardPower, true
+		case "length":
+			return g.Length, true
+		}
+		return nil, false
+	}, opts)
+}
+
+func (s *Storage) ListMemory(_ context.Context, filter storage.MemoryFilter, opts storage.ListOptions) ([]memory.Memory, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return list(s.memories, func(m memory.Memory) bool {
+		return containsFold(m.Name, filter.Name) &&
+			matchFold(m.StorageType, filter.StorageType) &&
+			matchFold(m.Interface, filter.Interface) &&
+			matchFold(m.FormFactor, filter.FormFactor) &&
+			matchInt64(int64(m.Capacity), filter.Capacity) &&
+			matchInt64(m.ReadSpeed, filter.ReadSpeed)
+	}, func(m memory.Memory, field string) (any, bool) {
+		switch field {
+		case "id":
+			return m.ID, true
+		case "name":
+			return m.Name, true
+		case "capacity":
+			return int64(m.Capacity), true
+		case "storage_type":
+			return m.StorageType, true
+		case "read_speed":
+			return m.ReadSpeed, true
+		case "write_speed":
+			return m.WriteSpeed, true
+		}
+		return nil, false
+	}, opts)
+}
+
+func (s *Storage) ListPeripheral(_ context.Context, filter storage.PeripheralFilter, opts storage.ListOptions) ([]peripheral.Peripheral, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res, next, err := list(s.peripherals, func(p peripheral.Peripheral) bool {
+		return containsFold(p.Name, filter.Name) &&
+			matchFold(p.Type, filter.Type) &&
+			matchFold(p.Manufacturer, filter.Manufacturer) &&
+			matchFold(p.Connection, filter.Connection)
+	}, func(p peripheral.Peripheral, field string) (any, bool) {
+		switch field {
+		case "id":
+			return p.ID, true
+		case "name":
+			return p.Name, true
+		case "type":
+			return p.Type, true
+		case "manufacturer":
+			return p.Manufacturer, true
+		}
+		return nil, false
+	}, opts)
+	for i := range res {
+		res[i] = res[i].WithOwnDetails()
+	}
+
+	return res, next, err
+}
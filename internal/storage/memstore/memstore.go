@@ -0,0 +1,669 @@
+// Package memstore is an in-memory storage.Repository for tests and demos.
+// It follows the same rules as the SQL backends: natural keys are unique,
+// a pc can only reference existing components and a component used by a pc
+// cannot be deleted.
+package memstore
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+type Storage struct {
+	mu sync.RWMutex
+
+	lastID map[string]int64
+
+	pcs      map[int64]pc.PC
+	rams     map[int64]ram.RAM
+	cpus     map[int64]cpu.CPU
+	gpus     map[int64]gpu.GPU
+	memories map[int64]memory.Memory
+
+	motherboards map[int64]motherboard.Motherboard
+	psus         map[int64]psu.PSU
+	cases        map[int64]pccase.Case
+	coolers      map[int64]cooler.Cooler
+	peripherals  map[int64]peripheral.Peripheral
+}
+
+var _ storage.Repository = (*Storage)(nil)
+
+func New() *Storage {
+	return &Storage{
+		lastID:   map[string]int64{},
+		pcs:      map[int64]pc.PC{},
+		rams:     map[int64]ram.RAM{},
+		cpus:     map[int64]cpu.CPU{},
+		gpus:     map[int64]gpu.GPU{},
+		memories: map[int64]memory.Memory{},
+
+		motherboards: map[int64]motherboard.Motherboard{},
+		psus:         map[int64]psu.PSU{},
+		cases:        map[int64]pccase.Case{},
+		coolers:      map[int64]cooler.Cooler{},
+		peripherals:  map[int64]peripheral.Peripheral{},
+	}
+}
+
+// nextID allocates ids per table, starting from 1 like SQLite rowids.
+func (s *Storage) nextID(table string) int64 {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+func (s *Storage) SavePC(_ context.Context, p pc.PC) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findPC(p.Name, 0); ok {
+		return id, storage.ErrPCAlreadyExists
+	}
+	if err := s.checkComponents(p); err != nil {
+		return 0, err
+	}
+
+	p.ID = s.nextID("pc")
+	s.pcs[p.ID] = clonePC(p)
+
+	return p.ID, nil
+}
+
+func (s *Storage) SaveRAM(_ context.Context, r ram.RAM) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findRAM(r); ok {
+		return id, storage.ErrRAMAlreadyExists
+	}
+
+	r.ID = s.nextID("ram")
+	s.rams[r.ID] = r
+
+	return r.ID, nil
+}
+
+func (s *Storage) SaveCPU(_ context.Context, c cpu.CPU) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findCPU(c); ok {
+		return id, storage.ErrCPUAlreadyExists
+	}
+
+	c.ID = s.nextID("cpu")
+	s.cpus[c.ID] = c
+
+	return c.ID, nil
+}
+
+func (s *Storage) SaveGPU(_ context.Context, g gpu.GPU) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findGPU(g); ok {
+		return id, storage.ErrGPUAlreadyExists
+	}
+
+	g.ID = s.nextID("gpu")
+	s.gpus[g.ID] = g
+
+	return g.ID, nil
+}
+
+func (s *Storage) SaveMemory(_ context.Context, m memory.Memory) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findMemory(m); ok {
+		return id, storage.ErrMemoryAlreadyExists
+	}
+
+	m.ID = s.nextID("memory")
+	s.memories[m.ID] = m
+
+	return m.ID, nil
+}
+
+// SaveBuild validates the whole build before storing anything, so a failed
+// build leaves no components behind. A new component matching an existing
+// one reuses it.
+func (s *Storage) SaveBuild(_ context.Context, b pc.Build) (*pc.PC, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.findPC(b.Name, 0); ok {
+		return nil, storage.ErrPCAlreadyExists
+	}
+
+	if b.CPU.ID != 0 {
+		if _, ok := s.cpus[b.CPU.ID]; !ok {
+			return nil, &storage.ComponentNotFoundError{Component: "cpu", ID: b.CPU.ID}
+		}
+	}
+	for _, r := range b.RAM {
+		if r.ID != 0 {
+			if _, ok := s.rams[r.ID]; !ok {
+				return nil, &storage.ComponentNotFoundError{Component: "ram", ID: r.ID}
+			}
+		}
+	}
+	for _, g := range b.GPU {
+		if g.ID != 0 {
+			if _, ok := s.gpus[g.ID]; !ok {
+				return nil, &storage.ComponentNotFoundError{Component: "gpu", ID: g.ID}
+			}
+		}
+	}
+	for _, m := range b.Memory {
+		if m.ID != 0 {
+			if _, ok := s.memories[m.ID]; !ok {
+				return nil, &storage.ComponentNotFoundError{Component: "memory", ID: m.ID}
+			}
+		}
+	}
+	if b.Motherboard != nil && b.Motherboard.ID != 0 {
+		if _, ok := s.motherboards[b.Motherboard.ID]; !ok {
+			return nil, &storage.ComponentNotFoundError{Component: "motherboard", ID: b.Motherboard.ID}
+		}
+	}
+	if b.PSU != nil && b.PSU.ID != 0 {
+		if _, ok := s.psus[b.PSU.ID]; !ok {
+			return nil, &storage.ComponentNotFoundError{Component: "psu", ID: b.PSU.ID}
+		}
+	}
+	if b.Case != nil && b.Case.ID != 0 {
+		if _, ok := s.cases[b.Case.ID]; !ok {
+			return nil, &storage.ComponentNotFoundError{Component: "case", ID: b.Case.ID}
+		}
+	}
+	if b.Cooler != nil && b.Cooler.ID != 0 {
+		if _, ok := s.coolers[b.Cooler.ID]; !ok {
+			return nil, &storage.ComponentNotFoundError{Component: "cooler", ID: b.Cooler.ID}
+		}
+	}
+
+	p := pc.PC{Name: b.Name, CPUID: b.CPU.ID}
+	if p.CPUID == 0 {
+		if p.CPUID, _ = s.findCPU(b.CPU); p.CPUID == 0 {
+			p.CPUID = s.nextID("cpu")
+			b.CPU.ID = p.CPUID
+			s.cpus[p.CPUID] = b.CPU
+		}
+	}
+	for _, r := range b.RAM {
+		if r.ID == 0 {
+			if r.ID, _ = s.findRAM(r.RAM); r.ID == 0 {
+				r.ID = s.nextID("ram")
+				s.rams[r.ID] = r.RAM
+			}
+		}
+		p.RAM = append(p.RAM, pc.Part{ID: r.ID, Quantity: r.Quantity, Slot: r.Slot})
+	}
+	for _, g := range b.GPU {
+		if g.ID == 0 {
+			if g.ID, _ = s.findGPU(g.GPU); g.ID == 0 {
+				g.ID = s.nextID("gpu")
+				s.gpus[g.ID] = g.GPU
+			}
+		}
+		p.GPU = append(p.GPU, pc.Part{ID: g.ID, Quantity: g.Quantity, Slot: g.Slot})
+	}
+	for _, m := range b.Memory {
+		if m.ID == 0 {
+			if m.ID, _ = s.findMemory(m.Memory); m.ID == 0 {
+				m.ID = s.nextID("memory")
+				s.memories[m.ID] = m.Memory
+			}
+		}
+		p.Memory = append(p.Memory, pc.Part{ID: m.ID, Quantity: m.Quantity, Slot: m.Slot})
+	}
+	if b.Motherboard != nil {
+		p.MotherboardID = b.Motherboard.ID
+		if p.MotherboardID == 0 {
+			if p.MotherboardID, _ = s.findMotherboard(*b.Motherboard); p.MotherboardID == 0 {
+				p.MotherboardID = s.nextID("motherboard")
+				b.Motherboard.ID = p.MotherboardID
+				s.motherboards[p.MotherboardID] = *b.Motherboard
+			}
+		}
+	}
+	if b.PSU != nil {
+		p.PSUID = b.PSU.ID
+		if p.PSUID == 0 {
+			if p.PSUID, _ = s.findPSU(*b.PSU); p.PSUID == 0 {
+				p.PSUID = s.nextID("psu")
+				b.PSU.ID = p.PSUID
+				s.psus[p.PSUID] = *b.PSU
+			}
+		}
+	}
+	if b.Case != nil {
+		p.CaseID = b.Case.ID
+		if p.CaseID == 0 {
+			if p.CaseID, _ = s.findCase(*b.Case); p.CaseID == 0 {
+				p.CaseID = s.nextID("pccase")
+				b.Case.ID = p.CaseID
+				s.cases[p.CaseID] = *b.Case
+			}
+		}
+	}
+	if b.Cooler != nil {
+		p.CoolerID = b.Cooler.ID
+		if p.CoolerID == 0 {
+			if p.CoolerID, _ = s.findCooler(*b.Cooler); p.CoolerID == 0 {
+				p.CoolerID = s.nextID("cooler")
+				b.Cooler.ID = p.CoolerID
+				s.coolers[p.CoolerID] = *b.Cooler
+			}
+		}
+	}
+
+	p.ID = s.nextID("pc")
+	s.pcs[p.ID] = p
+
+	res := clonePC(p)
+	return &res, nil
+}
+
+func (s *Storage) GetPC(_ context.Context, id int64) (*pc.PC, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.pcs[id]
+	if !ok {
+		return nil, storage.ErrPCNotFound
+	}
+
+	p = clonePC(p)
+	return &p, nil
+}
+
+func (s *Storage) GetExpandedPC(_ context.Context, id int64) (*pc.Expanded, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.pcs[id]
+	if !ok {
+		return nil, storage.ErrPCNotFound
+	}
+
+	res := pc.Expanded{
+		ID:   p.ID,
+		Name: p.Name,
+		CPU:  s.cpus[p.CPUID],
+	}
+	for _, part := range p.RAM {
+		res.RAM = append(res.RAM, pc.RAMPart{RAM: s.rams[part.ID], Quantity: part.Quantity, Slot: part.Slot})
+	}
+	for _, part := range p.GPU {
+		res.GPU = append(res.GPU, pc.GPUPart{GPU: s.gpus[part.ID], Quantity: part.Quantity, Slot: part.Slot})
+	}
+	for _, part := range p.Memory {
+		res.Memory = append(res.Memory, pc.MemoryPart{Memory: s.memories[part.ID], Quantity: part.Quantity, Slot: part.Slot})
+	}
+	for _, part := range p.Peripherals {
+		res.Peripherals = append(res.Peripherals, pc.PeripheralPart{Peripheral: s.peripherals[part.ID].WithOwnDetails(), Quantity: part.Quantity, Slot: part.Slot})
+	}
+	if m, ok := s.motherboards[p.MotherboardID]; ok {
+		res.Motherboard = &m
+	}
+	if ps, ok := s.psus[p.PSUID]; ok {
+		res.PSU = &ps
+	}
+	if c, ok := s.cases[p.CaseID]; ok {
+		res.Case = &c
+	}
+	if c, ok := s.coolers[p.CoolerID]; ok {
+		res.Cooler = &c
+	}
+
+	return &res, nil
+}
+
+func (s *Storage) GetRAM(_ context.Context, id int64) (*ram.RAM, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.rams[id]
+	if !ok {
+		return nil, storage.ErrRAMNotFound
+	}
+
+	return &r, nil
+}
+
+func (s *Storage) GetCPU(_ context.Context, id int64) (*cpu.CPU, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.cpus[id]
+	if !ok {
+		return nil, storage.ErrCPUNotFound
+	}
+
+	return &c, nil
+}
+
+func (s *Storage) GetGPU(_ context.Context, id int64) (*gpu.GPU, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	g, ok := s.gpus[id]
+	if !ok {
+		return nil, storage.ErrGPUNotFound
+	}
+
+	return &g, nil
+}
+
+func (s *Storage) GetMemory(_ context.Context, id int64) (*memory.Memory, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m, ok := s.memories[id]
+	if !ok {
+		return nil, storage.ErrMemoryNotFound
+	}
+
+	return &m, nil
+}
+
+func (s *Storage) UpdatePC(_ context.Context, p pc.PC) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pcs[p.ID]; !ok {
+		return storage.ErrPCNotFound
+	}
+	if _, ok := s.findPC(p.Name, p.ID); ok {
+		return storage.ErrPCAlreadyExists
+	}
+	if err := s.checkComponents(p); err != nil {
+		return err
+	}
+
+	s.pcs[p.ID] = clonePC(p)
+
+	return nil
+}
+
+func (s *Storage) UpdateRAM(_ context.Context, r ram.RAM) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rams[r.ID]; !ok {
+		return storage.ErrRAMNotFound
+	}
+
+	if existingID, ok := s.findRAM(r); ok && existingID != r.ID {
+		return storage.ErrRAMAlreadyExists
+	}
+	s.rams[r.ID] = r
+
+	return nil
+}
+
+func (s *Storage) UpdateCPU(_ context.Context, c cpu.CPU) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.cpus[c.ID]; !ok {
+		return storage.ErrCPUNotFound
+	}
+
+	if existingID, ok := s.findCPU(c); ok && existingID != c.ID {
+		return storage.ErrCPUAlreadyExists
+	}
+	s.cpus[c.ID] = c
+
+	return nil
+}
+
+func (s *Storage) UpdateGPU(_ context.Context, g gpu.GPU) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.gpus[g.ID]; !ok {
+		return storage.ErrGPUNotFound
+	}
+
+	if existingID, ok := s.findGPU(g); ok && existingID != g.ID {
+		return storage.ErrGPUAlreadyExists
+	}
+	s.gpus[g.ID] = g
+
+	return nil
+}
+
+func (s *Storage) UpdateMemory(_ context.Context, m memory.Memory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.memories[m.ID]; !ok {
+		return storage.ErrMemoryNotFound
+	}
+
+	if existingID, ok := s.findMemory(m); ok && existingID != m.ID {
+		return storage.ErrMemoryAlreadyExists
+	}
+	s.memories[m.ID] = m
+
+	return nil
+}
+
+func (s *Storage) DeletePC(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.pcs[id]; !ok {
+		return storage.ErrPCNotFound
+	}
+	delete(s.pcs, id)
+
+	return nil
+}
+
+func (s *Storage) DeleteRAM(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.rams[id]; !ok {
+		return storage.ErrRAMNotFound
+	}
+	if s.usedBy(func(p pc.PC) bool { return hasPart(p.RAM, id) }) {
+		return storage.ErrRAMInUse
+	}
+	delete(s.rams, id)
+
+	return nil
+}
+
+func (s *Storage) DeleteCPU(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.cpus[id]; !ok {
+		return storage.ErrCPUNotFound
+	}
+	if s.usedBy(func(p pc.PC) bool { return p.CPUID == id }) {
+		return storage.ErrCPUInUse
+	}
+	delete(s.cpus, id)
+
+	return nil
+}
+
+func (s *Storage) DeleteGPU(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.gpus[id]; !ok {
+		return storage.ErrGPUNotFound
+	}
+	if s.usedBy(func(p pc.PC) bool { return hasPart(p.GPU, id) }) {
+		return storage.ErrGPUInUse
+	}
+	delete(s.gpus, id)
+
+	return nil
+}
+
+func (s *Storage) DeleteMemory(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.memories[id]; !ok {
+		return storage.ErrMemoryNotFound
+	}
+	if s.usedBy(func(p pc.PC) bool { return hasPart(p.Memory, id) }) {
+		return storage.ErrMemoryInUse
+	}
+	delete(s.memories, id)
+
+	return nil
+}
+
+func (s *Storage) Close() error {
+	return nil
+}
+
+func (s *Storage) Ping() error {
+	return nil
+}
+
+// The find* helpers look up a row by its natural key, the same columns the
+// SQL backends put unique indexes on. Callers must hold the lock.
+
+func (s *Storage) findPC(name string, exceptID int64) (int64, bool) {
+	for id, p := range s.pcs {
+		if id != exceptID && p.Name == name {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Storage) findRAM(r ram.RAM) (int64, bool) {
+	for id, e := range s.rams {
+		if e.Name == r.Name && e.MemoryType == r.MemoryType && e.Capacity == r.Capacity {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Storage) findCPU(c cpu.CPU) (int64, bool) {
+	for id, e := range s.cpus {
+		if e.Name == c.Name && e.BaseClock == c.BaseClock {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Storage) findGPU(g gpu.GPU) (int64, bool) {
+	for id, e := range s.gpus {
+		if e.Manufacturer == g.Manufacturer && e.Name == g.Name && e.Memory == g.Memory {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Storage) findMemory(m memory.Memory) (int64, bool) {
+	for id, e := range s.memories {
+		if e.Name == m.Name && e.Capacity == m.Capacity && e.StorageType == m.StorageType {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Storage) checkComponents(p pc.PC) error {
+	if _, ok := s.cpus[p.CPUID]; !ok {
+		return &storage.ComponentNotFoundError{Component: "cpu", ID: p.CPUID}
+	}
+	for _, part := range p.RAM {
+		if _, ok := s.rams[part.ID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "ram", ID: part.ID}
+		}
+	}
+	for _, part := range p.GPU {
+		if _, ok := s.gpus[part.ID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "gpu", ID: part.ID}
+		}
+	}
+	for _, part := range p.Memory {
+		if _, ok := s.memories[part.ID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "memory", ID: part.ID}
+		}
+	}
+	for _, part := range p.Peripherals {
+		if _, ok := s.peripherals[part.ID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "peripheral", ID: part.ID}
+		}
+	}
+	if p.MotherboardID != 0 {
+		if _, ok := s.motherboards[p.MotherboardID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "motherboard", ID: p.MotherboardID}
+		}
+	}
+	if p.PSUID != 0 {
+		if _, ok := s.psus[p.PSUID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "psu", ID: p.PSUID}
+		}
+	}
+	if p.CaseID != 0 {
+		if _, ok := s.cases[p.CaseID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "case", ID: p.CaseID}
+		}
+	}
+	if p.CoolerID != 0 {
+		if _, ok := s.coolers[p.CoolerID]; !ok {
+			return &storage.ComponentNotFoundError{Component: "cooler", ID: p.CoolerID}
+		}
+	}
+	return nil
+}
+
+func (s *Storage) usedBy(match func(p pc.PC) bool) bool {
+	for _, p := range s.pcs {
+		if match(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPart(parts []pc.Part, id int64) bool {
+	return slices.ContainsFunc(parts, func(part pc.Part) bool { return part.ID == id })
+}
+
+// clonePC copies the part slices so stored pcs never share memory with the
+// caller.
+func clonePC(p pc.PC) pc.PC {
+	p.RAM = slices.Clone(p.RAM)
+	p.GPU = slices.Clone(p.GPU)
+	p.Memory = slices.Clone(p.Memory)
+	p.Peripherals = slices.Clone(p.Peripherals)
+	return p
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
@@ -0,0 +1,14 @@
+package memstore
+
+import (
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/storagetest"
+)
+
+func TestContract(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Repository {
+		return New()
+	})
+}
@@ -0,0 +1,43 @@
+package memstore
+
+import (
+	"context"
+
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+func (s *Storage) SaveMotherboard(_ context.Context, m motherboard.Motherboard) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findMotherboard(m); ok {
+		return id, storage.ErrMotherboardAlreadyExists
+	}
+
+	m.ID = s.nextID("motherboard")
+	s.motherboards[m.ID] = m
+
+	return m.ID, nil
+}
+
+func (s *Storage) GetMotherboard(_ context.Context, id int64) (*motherboard.Motherboard, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	m, ok := s.motherboards[id]
+	if !ok {
+		return nil, storage.ErrMotherboardNotFound
+	}
+
+	return &m, nil
+}
+
+func (s *Storage) findMotherboard(m motherboard.Motherboard) (int64, bool) {
+	for id, e := range s.motherboards {
+		if e.Name == m.Name {
+			return id, true
+		}
+	}
+	return 0, false
+}
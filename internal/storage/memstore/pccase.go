@@ -0,0 +1,43 @@
+package memstore
+
+import (
+	"context"
+
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+func (s *Storage) SaveCase(_ context.Context, c pccase.Case) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findCase(c); ok {
+		return id, storage.ErrCaseAlreadyExists
+	}
+
+	c.ID = s.nextID("pccase")
+	s.cases[c.ID] = c
+
+	return c.ID, nil
+}
+
+func (s *Storage) GetCase(_ context.Context, id int64) (*pccase.Case, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.cases[id]
+	if !ok {
+		return nil, storage.ErrCaseNotFound
+	}
+
+	return &c, nil
+}
+
+func (s *Storage) findCase(c pccase.Case) (int64, bool) {
+	for id, e := range s.cases {
+		if e.Name == c.Name {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,79 @@
+package memstore
+
+import (
+	"context"
+
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// Peripherals are stored and returned through WithOwnDetails, which copies
+// their details, so callers never share them with the store.
+
+func (s *Storage) SavePeripheral(_ context.Context, p peripheral.Peripheral) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findPeripheral(p); ok {
+		return id, storage.ErrPeripheralAlreadyExists
+	}
+
+	p.ID = s.nextID("peripheral")
+	s.peripherals[p.ID] = p.WithOwnDetails()
+
+	return p.ID, nil
+}
+
+func (s *Storage) GetPeripheral(_ context.Context, id int64) (*peripheral.Peripheral, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.peripherals[id]
+	if !ok {
+		return nil, storage.ErrPeripheralNotFound
+	}
+
+	p = p.WithOwnDetails()
+	return &p, nil
+}
+
+func (s *Storage) UpdatePeripheral(_ context.Context, p peripheral.Peripheral) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.peripherals[p.ID]; !ok {
+		return storage.ErrPeripheralNotFound
+	}
+
+	if existingID, ok := s.findPeripheral(p); ok && existingID != p.ID {
+		return storage.ErrPeripheralAlreadyExists
+	}
+	s.peripherals[p.ID] = p.WithOwnDetails()
+
+	return nil
+}
+
+func (s *Storage) DeletePeripheral(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.peripherals[id]; !ok {
+		return storage.ErrPeripheralNotFound
+	}
+	if s.usedBy(func(p pc.PC) bool { return hasPart(p.Peripherals, id) }) {
+		return storage.ErrPeripheralInUse
+	}
+	delete(s.peripherals, id)
+
+	return nil
+}
+
+func (s *Storage) findPeripheral(p peripheral.Peripheral) (int64, bool) {
+	for id, e := range s.peripherals {
+		if e.Manufacturer == p.Manufacturer && e.Name == p.Name {
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,43 @@
+package memstore
+
+import (
+	"context"
+
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+func (s *Storage) SavePSU(_ context.Context, p psu.PSU) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if id, ok := s.findPSU(p); ok {
+		return id, storage.ErrPSUAlreadyExists
+	}
+
+	p.ID = s.nextID("psu")
+	s.psus[p.ID] = p
+
+	return p.ID, nil
+}
+
+func (s *Storage) GetPSU(_ context.Context, id int64) (*psu.PSU, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.psus[id]
+	if !ok {
+		return nil, storage.ErrPSUNotFound
+	}
+
+	return &p, nil
+}
+
+func (s *Storage) findPSU(p psu.PSU) (int64, bool) {
+	for id, e := range s.psus {
+		if e.Name == p.Name {
+			return id, true
+		}
+	}
+	return 0, false
+}
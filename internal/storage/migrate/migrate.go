@@ -0,0 +1,280 @@
+// Package migrate applies versioned SQL schema migrations.
+//
+// Migrations are read from a file system with names like 0001_init.up.sql and
+// 0001_init.down.sql. Applied versions are recorded in the schema_version
+// table together with the checksum of their up script, so an edited migration
+// or a database created by a newer binary is detected before anything runs.
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrSchemaTooNew     = errors.New("database schema is newer than the known migrations")
+	ErrChecksumMismatch = errors.New("applied migration does not match its source")
+	ErrNoDown           = errors.New("migration has no down script")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New loads migrations from the root of fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads and orders the migrations found in the root of fsys.
+func Load(fsys fs.FS) ([]Migration, error) {
+	const op = "storage.migrate.Load"
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+
+		version, err := strconv.Atoi(m[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%s: invalid version in %s", op, e.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("%s: version %d has two names: %s and %s", op, version, mig.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mig.Up = string(body)
+			sum := sha256.Sum256(body)
+			mig.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	res := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("%s: migration %d_%s has no up script", op, mig.Version, mig.Name)
+		}
+		res = append(res, *mig)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+
+	return res, nil
+}
+
+// Latest returns the highest known migration version.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the currently applied schema version, 0 for an empty database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	const op = "storage.migrate.Version"
+
+	if err := m.init(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var version int
+	err := m.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
+
+// Verify checks that the database is not ahead of the known migrations and
+// that every applied migration still has the same checksum.
+func (m *Migrator) Verify(ctx context.Context) error {
+	const op = "storage.migrate.Verify"
+
+	if err := m.init(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, checksum FROM schema_version ORDER BY version")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		mig, ok := m.find(version)
+		if !ok {
+			return fmt.Errorf("%s: version %d: %w", op, version, ErrSchemaTooNew)
+		}
+		if mig.Checksum != checksum {
+			return fmt.Errorf("%s: %d_%s: %w", op, mig.Version, mig.Name, ErrChecksumMismatch)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Up verifies the database and applies every pending migration, each in its
+// own transaction.
+func (m *Migrator) Up(ctx context.Context) error {
+	const op = "storage.migrate.Up"
+
+	if err := m.Verify(ctx); err != nil {
+		return err
+	}
+
+	current, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range m.migrations {
+		if mig.Version <= current {
+			continue
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, mig.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_version (version, name, checksum) VALUES ($1, $2, $3)",
+				mig.Version, mig.Name, mig.Checksum,
+			)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %d_%s: %w", op, mig.Version, mig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Down reverts applied migrations, newest first, until the schema is at the
+// target version.
+func (m *Migrator) Down(ctx context.Context, target int) error {
+	const op = "storage.migrate.Down"
+
+	if err := m.Verify(ctx); err != nil {
+		return err
+	}
+
+	current, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mig := m.migrations[i]
+		if mig.Version <= target || mig.Version > current {
+			continue
+		}
+		if mig.Down == "" {
+			return fmt.Errorf("%s: %d_%s: %w", op, mig.Version, mig.Name, ErrNoDown)
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, mig.Down); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_version WHERE version = $1", mig.Version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("%s: %d_%s: %w", op, mig.Version, mig.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) init(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	return err
+}
+
+func (m *Migrator) find(version int) (Migration, bool) {
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			return mig, true
+		}
+	}
+	return Migration{}, false
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,43 @@
+CREATE TABLE ram (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	memory_type TEXT NOT NULL,
+	capacity BIGINT NOT NULL,
+	CONSTRAINT ram_natural_key UNIQUE (name, memory_type, capacity)
+);
+
+CREATE TABLE cpu (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	cores BIGINT NOT NULL,
+	threads BIGINT NOT NULL,
+	frequency BIGINT NOT NULL,
+	CONSTRAINT cpu_natural_key UNIQUE (name, frequency)
+);
+
+CREATE TABLE gpu (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	manufacturer TEXT NOT NULL,
+	memory BIGINT NOT NULL,
+	frequency BIGINT NOT NULL,
+	CONSTRAINT gpu_natural_key UNIQUE (manufacturer, name, memory)
+);
+
+CREATE TABLE memory (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	capacity BIGINT NOT NULL,
+	type TEXT NOT NULL,
+	CONSTRAINT memory_natural_key UNIQUE (name, capacity, type)
+);
+
+CREATE TABLE pc (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	ram_id BIGINT NOT NULL REFERENCES ram(id),
+	cpu_id BIGINT NOT NULL REFERENCES cpu(id),
+	gpu_id BIGINT NOT NULL REFERENCES gpu(id),
+	memory_id BIGINT NOT NULL REFERENCES memory(id),
+	CONSTRAINT pc_name_key UNIQUE (name)
+);
@@ -0,0 +1,22 @@
+-- Only the first part of each kind survives. PCs missing a kind of part
+-- cannot be represented in the old schema and are dropped.
+ALTER TABLE pc
+	ADD COLUMN ram_id BIGINT REFERENCES ram(id),
+	ADD COLUMN gpu_id BIGINT REFERENCES gpu(id),
+	ADD COLUMN memory_id BIGINT REFERENCES memory(id);
+
+UPDATE pc SET
+	ram_id = (SELECT ram_id FROM pc_ram WHERE pc_id = pc.id ORDER BY id LIMIT 1),
+	gpu_id = (SELECT gpu_id FROM pc_gpu WHERE pc_id = pc.id ORDER BY id LIMIT 1),
+	memory_id = (SELECT memory_id FROM pc_memory WHERE pc_id = pc.id ORDER BY id LIMIT 1);
+
+DROP TABLE pc_ram;
+DROP TABLE pc_gpu;
+DROP TABLE pc_memory;
+
+DELETE FROM pc WHERE ram_id IS NULL OR gpu_id IS NULL OR memory_id IS NULL;
+
+ALTER TABLE pc
+	ALTER COLUMN ram_id SET NOT NULL,
+	ALTER COLUMN gpu_id SET NOT NULL,
+	ALTER COLUMN memory_id SET NOT NULL;
@@ -0,0 +1,36 @@
+CREATE TABLE pc_ram (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	pc_id BIGINT NOT NULL REFERENCES pc(id) ON DELETE CASCADE,
+	ram_id BIGINT NOT NULL REFERENCES ram(id),
+	quantity BIGINT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE pc_gpu (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	pc_id BIGINT NOT NULL REFERENCES pc(id) ON DELETE CASCADE,
+	gpu_id BIGINT NOT NULL REFERENCES gpu(id),
+	quantity BIGINT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE pc_memory (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	pc_id BIGINT NOT NULL REFERENCES pc(id) ON DELETE CASCADE,
+	memory_id BIGINT NOT NULL REFERENCES memory(id),
+	quantity BIGINT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO pc_ram (pc_id, ram_id) SELECT id, ram_id FROM pc ORDER BY id;
+INSERT INTO pc_gpu (pc_id, gpu_id) SELECT id, gpu_id FROM pc ORDER BY id;
+INSERT INTO pc_memory (pc_id, memory_id) SELECT id, memory_id FROM pc ORDER BY id;
+
+ALTER TABLE pc DROP COLUMN ram_id, DROP COLUMN gpu_id, DROP COLUMN memory_id;
+
+CREATE INDEX pc_ram_pc_id ON pc_ram (pc_id);
+CREATE INDEX pc_ram_ram_id ON pc_ram (ram_id);
+CREATE INDEX pc_gpu_pc_id ON pc_gpu (pc_id);
+CREATE INDEX pc_gpu_gpu_id ON pc_gpu (gpu_id);
+CREATE INDEX pc_memory_pc_id ON pc_memory (pc_id);
+CREATE INDEX pc_memory_memory_id ON pc_memory (memory_id);
@@ -0,0 +1,2 @@
+ALTER TABLE pc DROP COLUMN motherboard_id;
+DROP TABLE motherboard;
@@ -0,0 +1,17 @@
+CREATE TABLE motherboard (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	socket TEXT NOT NULL,
+	chipset TEXT NOT NULL,
+	form_factor TEXT NOT NULL,
+	memory_type TEXT NOT NULL,
+	ram_slots BIGINT NOT NULL,
+	max_memory BIGINT NOT NULL DEFAULT 0,
+	m2_slots BIGINT NOT NULL DEFAULT 0,
+	sata_ports BIGINT NOT NULL DEFAULT 0,
+	CONSTRAINT motherboard_natural_key UNIQUE (name)
+);
+
+ALTER TABLE pc ADD COLUMN motherboard_id BIGINT REFERENCES motherboard(id);
+
+CREATE INDEX pc_motherboard_id ON pc (motherboard_id);
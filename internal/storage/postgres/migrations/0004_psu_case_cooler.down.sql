@@ -0,0 +1,6 @@
+ALTER TABLE pc DROP COLUMN cooler_id;
+ALTER TABLE pc DROP COLUMN case_id;
+ALTER TABLE pc DROP COLUMN psu_id;
+DROP TABLE cooler;
+DROP TABLE pccase;
+DROP TABLE psu;
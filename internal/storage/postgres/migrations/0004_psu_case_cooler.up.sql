@@ -0,0 +1,34 @@
+CREATE TABLE psu (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	wattage BIGINT NOT NULL,
+	efficiency TEXT NOT NULL,
+	modular BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT psu_natural_key UNIQUE (name)
+);
+
+CREATE TABLE pccase (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	form_factor TEXT NOT NULL,
+	max_gpu_length BIGINT NOT NULL DEFAULT 0,
+	max_cooler_height BIGINT NOT NULL DEFAULT 0,
+	CONSTRAINT pccase_natural_key UNIQUE (name)
+);
+
+CREATE TABLE cooler (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	type TEXT NOT NULL,
+	tdp BIGINT NOT NULL,
+	height BIGINT NOT NULL DEFAULT 0,
+	CONSTRAINT cooler_natural_key UNIQUE (name)
+);
+
+ALTER TABLE pc ADD COLUMN psu_id BIGINT REFERENCES psu(id);
+ALTER TABLE pc ADD COLUMN case_id BIGINT REFERENCES pccase(id);
+ALTER TABLE pc ADD COLUMN cooler_id BIGINT REFERENCES cooler(id);
+
+CREATE INDEX pc_psu_id ON pc (psu_id);
+CREATE INDEX pc_case_id ON pc (case_id);
+CREATE INDEX pc_cooler_id ON pc (cooler_id);
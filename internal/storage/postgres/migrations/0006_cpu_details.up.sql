@@ -0,0 +1,7 @@
+ALTER TABLE cpu RENAME COLUMN frequency TO base_clock;
+ALTER TABLE cpu ADD COLUMN vendor TEXT NOT NULL DEFAULT '';
+ALTER TABLE cpu ADD COLUMN socket TEXT NOT NULL DEFAULT '';
+ALTER TABLE cpu ADD COLUMN architecture TEXT NOT NULL DEFAULT '';
+ALTER TABLE cpu ADD COLUMN boost_clock BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE cpu ADD COLUMN l3_cache BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE cpu ADD COLUMN integrated_graphics BOOLEAN NOT NULL DEFAULT FALSE;
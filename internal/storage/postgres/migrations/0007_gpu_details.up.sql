@@ -0,0 +1,19 @@
+ALTER TABLE gpu ADD COLUMN chip_vendor TEXT NOT NULL DEFAULT '';
+ALTER TABLE gpu ADD COLUMN memory_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE gpu ADD COLUMN bus_width BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN pcie_gen BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN pcie_lanes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN length BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN hdmi_outputs BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN displayport_outputs BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN dvi_outputs BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN usbc_outputs BIGINT NOT NULL DEFAULT 0;
+
+-- Rows saved before chip_vendor existed often recorded the chip vendor as
+-- the manufacturer.
+UPDATE gpu SET chip_vendor = CASE UPPER(manufacturer)
+	WHEN 'NVIDIA' THEN 'NVIDIA'
+	WHEN 'AMD' THEN 'AMD'
+	WHEN 'INTEL' THEN 'Intel'
+	ELSE ''
+END;
@@ -0,0 +1,10 @@
+ALTER TABLE memory DROP COLUMN tbw;
+ALTER TABLE memory DROP COLUMN write_speed;
+ALTER TABLE memory DROP COLUMN read_speed;
+ALTER TABLE memory DROP COLUMN form_factor;
+ALTER TABLE memory DROP COLUMN interface;
+
+ALTER TABLE ram DROP COLUMN ecc;
+ALTER TABLE ram DROP COLUMN modules;
+ALTER TABLE ram DROP COLUMN cas_latency;
+ALTER TABLE ram DROP COLUMN speed;
@@ -0,0 +1,10 @@
+ALTER TABLE ram ADD COLUMN speed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE ram ADD COLUMN cas_latency BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE ram ADD COLUMN modules BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE ram ADD COLUMN ecc BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE memory ADD COLUMN interface TEXT NOT NULL DEFAULT '';
+ALTER TABLE memory ADD COLUMN form_factor TEXT NOT NULL DEFAULT '';
+ALTER TABLE memory ADD COLUMN read_speed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE memory ADD COLUMN write_speed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE memory ADD COLUMN tbw BIGINT NOT NULL DEFAULT 0;
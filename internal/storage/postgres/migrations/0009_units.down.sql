@@ -0,0 +1,45 @@
+-- Values the up migration converted and nobody changed since get their
+-- legacy value back, anything else is read in the unit the column usually
+-- had. Parts merged by the up migration stay merged.
+UPDATE motherboard SET max_memory = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'motherboard' AND column_name = 'max_memory' AND row_id = motherboard.id
+		AND legacy_value * factor = motherboard.max_memory
+), max_memory / 1073741824);
+UPDATE cpu SET base_clock = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'cpu' AND column_name = 'base_clock' AND row_id = cpu.id
+		AND legacy_value * factor = cpu.base_clock
+), base_clock / 1000000);
+UPDATE cpu SET boost_clock = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'cpu' AND column_name = 'boost_clock' AND row_id = cpu.id
+		AND legacy_value * factor = cpu.boost_clock
+), boost_clock / 1000000);
+UPDATE cpu SET l3_cache = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'cpu' AND column_name = 'l3_cache' AND row_id = cpu.id
+		AND legacy_value * factor = cpu.l3_cache
+), l3_cache / 1048576);
+UPDATE gpu SET memory = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'gpu' AND column_name = 'memory' AND row_id = gpu.id
+		AND legacy_value * factor = gpu.memory
+), memory / 1073741824);
+UPDATE gpu SET frequency = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'gpu' AND column_name = 'frequency' AND row_id = gpu.id
+		AND legacy_value * factor = gpu.frequency
+), frequency / 1000000);
+UPDATE memory SET capacity = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'memory' AND column_name = 'capacity' AND row_id = memory.id
+		AND legacy_value * factor = memory.capacity
+), capacity / 1000000000);
+UPDATE ram SET capacity = COALESCE((
+	SELECT legacy_value FROM legacy_units
+	WHERE table_name = 'ram' AND column_name = 'capacity' AND row_id = ram.id
+		AND legacy_value * factor = ram.capacity
+), capacity / 1073741824);
+
+DROP TABLE legacy_units;
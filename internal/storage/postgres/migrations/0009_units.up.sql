@@ -0,0 +1,162 @@
+-- Sizes are stored in bytes and frequencies in hertz. Rows saved before
+-- carried no unit and clients did not agree on one, the same ram kit was
+-- saved as 16 (GiB) or 16384 (MiB). The unit of every value is inferred from
+-- its size and kept in legacy_units with the value, so the down migration
+-- can restore it. Values fitting no unit are read in the unit the column
+-- usually had and marked needs_review:
+--
+--	SELECT * FROM legacy_units WHERE needs_review;
+CREATE TABLE legacy_units (
+	table_name TEXT NOT NULL,
+	column_name TEXT NOT NULL,
+	row_id BIGINT NOT NULL,
+	legacy_value BIGINT NOT NULL,
+	unit TEXT NOT NULL,
+	factor BIGINT NOT NULL DEFAULT 0,
+	needs_review BOOLEAN NOT NULL,
+	PRIMARY KEY (table_name, column_name, row_id)
+);
+
+-- ram kits up to 512 GiB, or MiB from 1024 on.
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'ram', 'capacity', id, capacity,
+	CASE WHEN capacity >= 1024 THEN 'MiB' ELSE 'GiB' END,
+	capacity NOT BETWEEN 1 AND 512 AND capacity < 1024
+FROM ram WHERE capacity <> 0;
+
+-- drives up to 30 TB in GB, or MB from 100000 (100 GB) on.
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'memory', 'capacity', id, capacity,
+	CASE WHEN capacity >= 100000 THEN 'MB' ELSE 'GB' END,
+	capacity NOT BETWEEN 1 AND 30000 AND capacity < 100000
+FROM memory WHERE capacity <> 0;
+
+-- gpu memory up to 192 GiB, or MiB from 1024 on.
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'gpu', 'memory', id, memory,
+	CASE WHEN memory >= 1024 THEN 'MiB' ELSE 'GiB' END,
+	memory NOT BETWEEN 1 AND 192 AND memory < 1024
+FROM gpu WHERE memory <> 0;
+
+-- motherboards up to 6144 GiB, or MiB from 8192 on.
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'motherboard', 'max_memory', id, max_memory,
+	CASE WHEN max_memory >= 8192 THEN 'MiB' ELSE 'GiB' END,
+	max_memory NOT BETWEEN 1 AND 6144 AND max_memory < 8192
+FROM motherboard WHERE max_memory <> 0;
+
+-- l3 cache up to 1152 MiB, or KiB from 2048 on.
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'cpu', 'l3_cache', id, l3_cache,
+	CASE WHEN l3_cache >= 2048 THEN 'KiB' ELSE 'MiB' END,
+	l3_cache NOT BETWEEN 1 AND 1152 AND l3_cache < 2048
+FROM cpu WHERE l3_cache <> 0;
+
+-- clocks from 100 to 9999 MHz, or whole GHz below 10.
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'cpu', 'base_clock', id, base_clock,
+	CASE WHEN base_clock BETWEEN 1 AND 9 THEN 'GHz' ELSE 'MHz' END,
+	base_clock NOT BETWEEN 1 AND 9 AND base_clock NOT BETWEEN 100 AND 9999
+FROM cpu WHERE base_clock <> 0;
+
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'cpu', 'boost_clock', id, boost_clock,
+	CASE WHEN boost_clock BETWEEN 1 AND 9 THEN 'GHz' ELSE 'MHz' END,
+	boost_clock NOT BETWEEN 1 AND 9 AND boost_clock NOT BETWEEN 100 AND 9999
+FROM cpu WHERE boost_clock <> 0;
+
+INSERT INTO legacy_units (table_name, column_name, row_id, legacy_value, unit, needs_review)
+SELECT 'gpu', 'frequency', id, frequency,
+	CASE WHEN frequency BETWEEN 1 AND 9 THEN 'GHz' ELSE 'MHz' END,
+	frequency NOT BETWEEN 1 AND 9 AND frequency NOT BETWEEN 100 AND 9999
+FROM gpu WHERE frequency <> 0;
+
+UPDATE legacy_units SET factor = CASE unit
+	WHEN 'KiB' THEN 1024
+	WHEN 'MiB' THEN 1048576
+	WHEN 'GiB' THEN 1073741824
+	WHEN 'MB' THEN 1000000
+	WHEN 'GB' THEN 1000000000
+	WHEN 'MHz' THEN 1000000
+	WHEN 'GHz' THEN 1000000000
+END;
+
+-- The natural keys hold sizes and clocks, rows that were the same part in
+-- different units collide once converted. They are dropped here and the
+-- duplicates merged into the row with the lowest id.
+ALTER TABLE ram DROP CONSTRAINT ram_natural_key;
+ALTER TABLE cpu DROP CONSTRAINT cpu_natural_key;
+ALTER TABLE gpu DROP CONSTRAINT gpu_natural_key;
+ALTER TABLE memory DROP CONSTRAINT memory_natural_key;
+
+UPDATE ram SET capacity = capacity * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'ram' AND column_name = 'capacity' AND row_id = ram.id
+) WHERE capacity <> 0;
+UPDATE memory SET capacity = capacity * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'memory' AND column_name = 'capacity' AND row_id = memory.id
+) WHERE capacity <> 0;
+UPDATE gpu SET memory = memory * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'gpu' AND column_name = 'memory' AND row_id = gpu.id
+) WHERE memory <> 0;
+UPDATE gpu SET frequency = frequency * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'gpu' AND column_name = 'frequency' AND row_id = gpu.id
+) WHERE frequency <> 0;
+UPDATE motherboard SET max_memory = max_memory * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'motherboard' AND column_name = 'max_memory' AND row_id = motherboard.id
+) WHERE max_memory <> 0;
+UPDATE cpu SET l3_cache = l3_cache * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'cpu' AND column_name = 'l3_cache' AND row_id = cpu.id
+) WHERE l3_cache <> 0;
+UPDATE cpu SET base_clock = base_clock * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'cpu' AND column_name = 'base_clock' AND row_id = cpu.id
+) WHERE base_clock <> 0;
+UPDATE cpu SET boost_clock = boost_clock * (
+	SELECT factor FROM legacy_units
+	WHERE table_name = 'cpu' AND column_name = 'boost_clock' AND row_id = cpu.id
+) WHERE boost_clock <> 0;
+
+UPDATE pc_ram SET ram_id = (
+	SELECT MIN(d.id) FROM ram r JOIN ram d
+		ON d.name = r.name AND d.memory_type = r.memory_type AND d.capacity = r.capacity
+	WHERE r.id = pc_ram.ram_id
+);
+DELETE FROM ram WHERE id NOT IN (SELECT MIN(id) FROM ram GROUP BY name, memory_type, capacity);
+
+UPDATE pc SET cpu_id = (
+	SELECT MIN(d.id) FROM cpu c JOIN cpu d
+		ON d.name = c.name AND d.base_clock = c.base_clock
+	WHERE c.id = pc.cpu_id
+);
+DELETE FROM cpu WHERE id NOT IN (SELECT MIN(id) FROM cpu GROUP BY name, base_clock);
+
+UPDATE pc_gpu SET gpu_id = (
+	SELECT MIN(d.id) FROM gpu g JOIN gpu d
+		ON d.manufacturer = g.manufacturer AND d.name = g.name AND d.memory = g.memory
+	WHERE g.id = pc_gpu.gpu_id
+);
+DELETE FROM gpu WHERE id NOT IN (SELECT MIN(id) FROM gpu GROUP BY manufacturer, name, memory);
+
+UPDATE pc_memory SET memory_id = (
+	SELECT MIN(d.id) FROM memory m JOIN memory d
+		ON d.name = m.name AND d.capacity = m.capacity AND d.type = m.type
+	WHERE m.id = pc_memory.memory_id
+);
+DELETE FROM memory WHERE id NOT IN (SELECT MIN(id) FROM memory GROUP BY name, capacity, type);
+
+DELETE FROM legacy_units WHERE
+	(table_name = 'ram' AND row_id NOT IN (SELECT id FROM ram)) OR
+	(table_name = 'cpu' AND row_id NOT IN (SELECT id FROM cpu)) OR
+	(table_name = 'gpu' AND row_id NOT IN (SELECT id FROM gpu)) OR
+	(table_name = 'memory' AND row_id NOT IN (SELECT id FROM memory));
+
+ALTER TABLE ram ADD CONSTRAINT ram_natural_key UNIQUE (name, memory_type, capacity);
+ALTER TABLE cpu ADD CONSTRAINT cpu_natural_key UNIQUE (name, base_clock);
+ALTER TABLE gpu ADD CONSTRAINT gpu_natural_key UNIQUE (manufacturer, name, memory);
+ALTER TABLE memory ADD CONSTRAINT memory_natural_key UNIQUE (name, capacity, type);
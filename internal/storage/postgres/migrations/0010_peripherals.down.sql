@@ -0,0 +1,2 @@
+DROP TABLE pc_peripheral;
+DROP TABLE peripheral;
@@ -0,0 +1,32 @@
+-- Peripherals of every type share one table, columns of the details a type
+-- does not have stay at their defaults.
+CREATE TABLE peripheral (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	name TEXT NOT NULL,
+	type TEXT NOT NULL,
+	manufacturer TEXT NOT NULL DEFAULT '',
+	connection TEXT NOT NULL DEFAULT '',
+	diagonal DOUBLE PRECISION NOT NULL DEFAULT 0,
+	width BIGINT NOT NULL DEFAULT 0,
+	height BIGINT NOT NULL DEFAULT 0,
+	refresh_rate BIGINT NOT NULL DEFAULT 0,
+	panel TEXT NOT NULL DEFAULT '',
+	layout TEXT NOT NULL DEFAULT '',
+	switches TEXT NOT NULL DEFAULT '',
+	speed BIGINT NOT NULL DEFAULT 0,
+	ports BIGINT NOT NULL DEFAULT 0,
+	standard TEXT NOT NULL DEFAULT '',
+	bluetooth BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT peripheral_natural_key UNIQUE (manufacturer, name)
+);
+
+CREATE TABLE pc_peripheral (
+	id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+	pc_id BIGINT NOT NULL REFERENCES pc(id) ON DELETE CASCADE,
+	peripheral_id BIGINT NOT NULL REFERENCES peripheral(id),
+	quantity BIGINT NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX pc_peripheral_pc_id ON pc_peripheral (pc_id);
+CREATE INDEX pc_peripheral_peripheral_id ON pc_peripheral (peripheral_id);
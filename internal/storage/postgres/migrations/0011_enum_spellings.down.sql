@@ -0,0 +1,25 @@
+-- Values the up migration normalized and nobody changed since get their old
+-- spelling back. Parts merged by the up migration stay merged.
+UPDATE memory SET interface = (
+	SELECT legacy_value FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'interface' AND row_id = memory.id
+) WHERE id IN (
+	SELECT row_id FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'interface' AND value = memory.interface
+);
+UPDATE memory SET type = (
+	SELECT legacy_value FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'type' AND row_id = memory.id
+) WHERE id IN (
+	SELECT row_id FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'type' AND value = memory.type
+);
+UPDATE ram SET memory_type = (
+	SELECT legacy_value FROM legacy_enums
+	WHERE table_name = 'ram' AND column_name = 'memory_type' AND row_id = ram.id
+) WHERE id IN (
+	SELECT row_id FROM legacy_enums
+	WHERE table_name = 'ram' AND column_name = 'memory_type' AND value = ram.memory_type
+);
+
+DROP TABLE legacy_enums;
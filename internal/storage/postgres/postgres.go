@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+
+	"github.com/lib/pq"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/migrate"
+	"github.com/r33ta/pc-database-manager/internal/storage/sqlquery"
+	"github.com/r33ta/pc-database-manager/internal/storage/sqlstore"
+)
+
+// error codes from https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	uniqueViolation     pq.ErrorCode = "23505"
+	foreignKeyViolation pq.ErrorCode = "23503"
+)
+
+type Storage struct {
+	*sqlstore.Storage
+	db *sql.DB
+}
+
+var _ storage.Repository = (*Storage)(nil)
+
+// dialect is the SQL Postgres speaks.
+var dialect = sqlquery.Dialect{
+	Placeholder:  func(n int) string { return "$" + strconv.Itoa(n) },
+	ILike:        "ILIKE",
+	IsUnique:     func(err error) bool { return isViolation(err, uniqueViolation) },
+	IsForeignKey: func(err error) bool { return isViolation(err, foreignKeyViolation) },
+}
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// New connects to the database and migrates its schema to the latest version.
+func New(dsn string) (*Storage, error) {
+	const op = "storage.postgres.New"
+
+	s, err := Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.Migrator()
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := m.Up(context.Background()); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return s, nil
+}
+
+// Open connects to the database without touching its schema.
+func Open(dsn string) (*Storage, error) {
+	const op = "storage.postgres.Open"
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Storage{Storage: sqlstore.New(db, dialect), db: db}, nil
+}
+
+// Migrator returns the schema migrator for the database.
+func (s *Storage) Migrator() (*migrate.Migrator, error) {
+	sub, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return migrate.New(s.db, sub)
+}
+
+func isViolation(err error, code pq.ErrorCode) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/storagetest"
+)
+
+// TestContract needs a database it may wipe, given as a DSN in
+// POSTGRES_TEST_DSN; make test-postgres starts one with docker compose.
+func TestContract(t *testing.T) {
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set, run make test-postgres")
+	}
+
+	storagetest.Run(t, func(t *testing.T) storage.Repository {
+		s, err := New(dsn)
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+
+		_, err = s.db.Exec(`TRUNCATE pc, pc_ram, pc_gpu, pc_memory, pc_peripheral,
+			ram, cpu, gpu, memory, motherboard, psu, pccase, cooler, peripheral
+			RESTART IDENTITY CASCADE`)
+		if err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+
+		return s
+	})
+}
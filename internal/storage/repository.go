@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+)
+
+// Storage drivers accepted in the storage_driver config field.
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+	DriverMemory   = "memory"
+)
+
+// Repository is everything a storage backend has to provide. Handlers keep
+// depending on their own narrow interfaces, any Repository satisfies them.
+type Repository interface {
+	PCRepository
+	RAMRepository
+	CPURepository
+	GPURepository
+	MemoryRepository
+	MotherboardRepository
+	PSURepository
+	CaseRepository
+	CoolerRepository
+	PeripheralRepository
+	BuildRepository
+
+	Ping() error
+	Close() error
+}
+
+type PCRepository interface {
+	SavePC(ctx context.Context, p pc.PC) (int64, error)
+	GetPC(ctx context.Context, id int64) (*pc.PC, error)
+	GetExpandedPC(ctx context.Context, id int64) (*pc.Expanded, error)
+	ListPC(ctx context.Context, filter PCFilter, opts ListOptions) ([]pc.PC, string, error)
+	UpdatePC(ctx context.Context, p pc.PC) error
+	DeletePC(ctx context.Context, id int64) error
+}
+
+type RAMRepository interface {
+	SaveRAM(ctx context.Context, r ram.RAM) (int64, error)
+	GetRAM(ctx context.Context, id int64) (*ram.RAM, error)
+	ListRAM(ctx context.Context, filter RAMFilter, opts ListOptions) ([]ram.RAM, string, error)
+	UpdateRAM(ctx context.Context, r ram.RAM) error
+	DeleteRAM(ctx context.Context, id int64) error
+}
+
+type CPURepository interface {
+	SaveCPU(ctx context.Context, c cpu.CPU) (int64, error)
+	GetCPU(ctx context.Context, id int64) (*cpu.CPU, error)
+	ListCPU(ctx context.Context, filter CPUFilter, opts ListOptions) ([]cpu.CPU, string, error)
+	UpdateCPU(ctx context.Context, c cpu.CPU) error
+	DeleteCPU(ctx context.Context, id int64) error
+}
+
+type GPURepository interface {
+	SaveGPU(ctx context.Context, g gpu.GPU) (int64, error)
+	GetGPU(ctx context.Context, id int64) (*gpu.GPU, error)
+	ListGPU(ctx context.Context, filter GPUFilter, opts ListOptions) ([]gpu.GPU, string, error)
+	UpdateGPU(ctx context.Context, g gpu.GPU) error
+	DeleteGPU(ctx context.Context, id int64) error
+}
+
+type MemoryRepository interface {
+	SaveMemory(ctx context.Context, m memory.Memory) (int64, error)
+	GetMemory(ctx context.Context, id int64) (*memory.Memory, error)
+	ListMemory(ctx context.Context, filter MemoryFilter, opts ListOptions) ([]memory.Memory, string, error)
+	UpdateMemory(ctx context.Context, m memory.Memory) error
+	DeleteMemory(ctx context.Context, id int64) error
+}
+
+type MotherboardRepository interface {
+	SaveMotherboard(ctx context.Context, m motherboard.Motherboard) (int64, error)
+	GetMotherboard(ctx context.Context, id int64) (*motherboard.Motherboard, error)
+}
+
+type PSURepository interface {
+	SavePSU(ctx context.Context, p psu.PSU) (int64, error)
+	GetPSU(ctx context.Context, id int64) (*psu.PSU, error)
+}
+
+type CaseRepository interface {
+	SaveCase(ctx context.Context, c pccase.Case) (int64, error)
+	GetCase(ctx context.Context, id int64) (*pccase.Case, error)
+}
+
+type CoolerRepository interface {
+	SaveCooler(ctx context.Context, c cooler.Cooler) (int64, error)
+	GetCooler(ctx context.Context, id int64) (*cooler.Cooler, error)
+}
+
+type PeripheralRepository interface {
+	SavePeripheral(ctx context.Context, p peripheral.Peripheral) (int64, error)
+	GetPeripheral(ctx context.Context, id int64) (*peripheral.Peripheral, error)
+	ListPeripheral(ctx context.Context, filter PeripheralFilter, opts ListOptions) ([]peripheral.Peripheral, string, error)
+	UpdatePeripheral(ctx context.Context, p peripheral.Peripheral) error
+	DeletePeripheral(ctx context.Context, id int64) error
+}
+
+type BuildRepository interface {
+	SaveBuild(ctx context.Context, b pc.Build) (*pc.PC, error)
+}
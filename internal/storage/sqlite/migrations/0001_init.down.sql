@@ -0,0 +1,5 @@
+DROP TABLE pc;
+DROP TABLE ram;
+DROP TABLE cpu;
+DROP TABLE gpu;
+DROP TABLE memory;
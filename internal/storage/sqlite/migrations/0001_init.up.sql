@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS pc (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	ram_id INTEGER NOT NULL,
+	cpu_id INTEGER NOT NULL,
+	gpu_id INTEGER NOT NULL,
+	memory_id INTEGER NOT NULL,
+	FOREIGN KEY(ram_id) REFERENCES ram(id),
+	FOREIGN KEY(cpu_id) REFERENCES cpu(id),
+	FOREIGN KEY(gpu_id) REFERENCES gpu(id),
+	FOREIGN KEY(memory_id) REFERENCES memory(id)
+);
+
+CREATE TABLE IF NOT EXISTS ram (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	memory_type TEXT NOT NULL,
+	capacity INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cpu (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	cores INTEGER NOT NULL,
+	threads INTEGER NOT NULL,
+	frequency INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS gpu (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	manufacturer TEXT NOT NULL,
+	memory INTEGER NOT NULL,
+	frequency INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS memory (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	capacity INTEGER NOT NULL,
+	type TEXT NOT NULL
+);
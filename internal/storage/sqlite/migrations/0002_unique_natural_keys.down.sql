@@ -0,0 +1,5 @@
+DROP INDEX pc_name_key;
+DROP INDEX ram_natural_key;
+DROP INDEX cpu_natural_key;
+DROP INDEX gpu_natural_key;
+DROP INDEX memory_natural_key;
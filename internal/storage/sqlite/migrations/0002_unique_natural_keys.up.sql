@@ -0,0 +1,39 @@
+-- Merge duplicate components into the row with the lowest id, pointing pcs at
+-- the surviving row first so foreign keys stay valid.
+UPDATE pc SET ram_id = (
+	SELECT MIN(d.id) FROM ram r JOIN ram d
+		ON d.name = r.name AND d.memory_type = r.memory_type AND d.capacity = r.capacity
+	WHERE r.id = pc.ram_id
+) WHERE ram_id IN (SELECT id FROM ram);
+DELETE FROM ram WHERE id NOT IN (SELECT MIN(id) FROM ram GROUP BY name, memory_type, capacity);
+
+UPDATE pc SET cpu_id = (
+	SELECT MIN(d.id) FROM cpu c JOIN cpu d
+		ON d.name = c.name AND d.frequency = c.frequency
+	WHERE c.id = pc.cpu_id
+) WHERE cpu_id IN (SELECT id FROM cpu);
+DELETE FROM cpu WHERE id NOT IN (SELECT MIN(id) FROM cpu GROUP BY name, frequency);
+
+UPDATE pc SET gpu_id = (
+	SELECT MIN(d.id) FROM gpu g JOIN gpu d
+		ON d.manufacturer = g.manufacturer AND d.name = g.name AND d.memory = g.memory
+	WHERE g.id = pc.gpu_id
+) WHERE gpu_id IN (SELECT id FROM gpu);
+DELETE FROM gpu WHERE id NOT IN (SELECT MIN(id) FROM gpu GROUP BY manufacturer, name, memory);
+
+UPDATE pc SET memory_id = (
+	SELECT MIN(d.id) FROM memory m JOIN memory d
+		ON d.name = m.name AND d.capacity = m.capacity AND d.type = m.type
+	WHERE m.id = pc.memory_id
+) WHERE memory_id IN (SELECT id FROM memory);
+DELETE FROM memory WHERE id NOT IN (SELECT MIN(id) FROM memory GROUP BY name, capacity, type);
+
+-- PCs are not merged, duplicates get their id appended to the name instead.
+UPDATE pc SET name = name || ' (' || id || ')'
+WHERE id NOT IN (SELECT MIN(id) FROM pc GROUP BY name);
+
+CREATE UNIQUE INDEX pc_name_key ON pc (name);
+CREATE UNIQUE INDEX ram_natural_key ON ram (name, memory_type, capacity);
+CREATE UNIQUE INDEX cpu_natural_key ON cpu (name, frequency);
+CREATE UNIQUE INDEX gpu_natural_key ON gpu (manufacturer, name, memory);
+CREATE UNIQUE INDEX memory_natural_key ON memory (name, capacity, type);
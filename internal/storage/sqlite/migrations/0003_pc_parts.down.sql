@@ -0,0 +1,33 @@
+-- Only the first part of each kind survives. PCs missing a kind of part
+-- cannot be represented in the old schema and are dropped.
+ALTER TABLE pc RENAME TO pc_new;
+
+CREATE TABLE pc (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	ram_id INTEGER NOT NULL,
+	cpu_id INTEGER NOT NULL,
+	gpu_id INTEGER NOT NULL,
+	memory_id INTEGER NOT NULL,
+	FOREIGN KEY(ram_id) REFERENCES ram(id),
+	FOREIGN KEY(cpu_id) REFERENCES cpu(id),
+	FOREIGN KEY(gpu_id) REFERENCES gpu(id),
+	FOREIGN KEY(memory_id) REFERENCES memory(id)
+);
+
+INSERT INTO pc (id, name, ram_id, cpu_id, gpu_id, memory_id)
+SELECT * FROM (
+	SELECT p.id, p.name,
+		(SELECT ram_id FROM pc_ram WHERE pc_id = p.id ORDER BY id LIMIT 1) AS ram_id,
+		p.cpu_id,
+		(SELECT gpu_id FROM pc_gpu WHERE pc_id = p.id ORDER BY id LIMIT 1) AS gpu_id,
+		(SELECT memory_id FROM pc_memory WHERE pc_id = p.id ORDER BY id LIMIT 1) AS memory_id
+	FROM pc_new p
+) WHERE ram_id IS NOT NULL AND gpu_id IS NOT NULL AND memory_id IS NOT NULL;
+
+DROP TABLE pc_ram;
+DROP TABLE pc_gpu;
+DROP TABLE pc_memory;
+DROP TABLE pc_new;
+
+CREATE UNIQUE INDEX pc_name_key ON pc (name);
@@ -0,0 +1,57 @@
+-- pc keeps only the cpu, every other component moves to a junction table
+-- so a pc can hold several of them. The old table is renamed first: dropping
+-- it after the junction tables reference pc would cascade into them.
+ALTER TABLE pc RENAME TO pc_old;
+
+CREATE TABLE pc (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	cpu_id INTEGER NOT NULL,
+	FOREIGN KEY(cpu_id) REFERENCES cpu(id)
+);
+
+INSERT INTO pc (id, name, cpu_id) SELECT id, name, cpu_id FROM pc_old;
+
+CREATE TABLE pc_ram (
+	id INTEGER PRIMARY KEY,
+	pc_id INTEGER NOT NULL,
+	ram_id INTEGER NOT NULL,
+	quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT '',
+	FOREIGN KEY(pc_id) REFERENCES pc(id) ON DELETE CASCADE,
+	FOREIGN KEY(ram_id) REFERENCES ram(id)
+);
+
+CREATE TABLE pc_gpu (
+	id INTEGER PRIMARY KEY,
+	pc_id INTEGER NOT NULL,
+	gpu_id INTEGER NOT NULL,
+	quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT '',
+	FOREIGN KEY(pc_id) REFERENCES pc(id) ON DELETE CASCADE,
+	FOREIGN KEY(gpu_id) REFERENCES gpu(id)
+);
+
+CREATE TABLE pc_memory (
+	id INTEGER PRIMARY KEY,
+	pc_id INTEGER NOT NULL,
+	memory_id INTEGER NOT NULL,
+	quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT '',
+	FOREIGN KEY(pc_id) REFERENCES pc(id) ON DELETE CASCADE,
+	FOREIGN KEY(memory_id) REFERENCES memory(id)
+);
+
+INSERT INTO pc_ram (pc_id, ram_id) SELECT id, ram_id FROM pc_old ORDER BY id;
+INSERT INTO pc_gpu (pc_id, gpu_id) SELECT id, gpu_id FROM pc_old ORDER BY id;
+INSERT INTO pc_memory (pc_id, memory_id) SELECT id, memory_id FROM pc_old ORDER BY id;
+
+DROP TABLE pc_old;
+
+CREATE UNIQUE INDEX pc_name_key ON pc (name);
+CREATE INDEX pc_ram_pc_id ON pc_ram (pc_id);
+CREATE INDEX pc_ram_ram_id ON pc_ram (ram_id);
+CREATE INDEX pc_gpu_pc_id ON pc_gpu (pc_id);
+CREATE INDEX pc_gpu_gpu_id ON pc_gpu (gpu_id);
+CREATE INDEX pc_memory_pc_id ON pc_memory (pc_id);
+CREATE INDEX pc_memory_memory_id ON pc_memory (memory_id);
@@ -0,0 +1,3 @@
+DROP INDEX pc_motherboard_id;
+ALTER TABLE pc DROP COLUMN motherboard_id;
+DROP TABLE motherboard;
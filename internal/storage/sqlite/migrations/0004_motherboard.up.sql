@@ -0,0 +1,18 @@
+CREATE TABLE motherboard (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	socket TEXT NOT NULL,
+	chipset TEXT NOT NULL,
+	form_factor TEXT NOT NULL,
+	memory_type TEXT NOT NULL,
+	ram_slots INTEGER NOT NULL,
+	max_memory INTEGER NOT NULL DEFAULT 0,
+	m2_slots INTEGER NOT NULL DEFAULT 0,
+	sata_ports INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX motherboard_natural_key ON motherboard (name);
+
+ALTER TABLE pc ADD COLUMN motherboard_id INTEGER REFERENCES motherboard(id);
+
+CREATE INDEX pc_motherboard_id ON pc (motherboard_id);
@@ -0,0 +1,9 @@
+DROP INDEX pc_cooler_id;
+DROP INDEX pc_case_id;
+DROP INDEX pc_psu_id;
+ALTER TABLE pc DROP COLUMN cooler_id;
+ALTER TABLE pc DROP COLUMN case_id;
+ALTER TABLE pc DROP COLUMN psu_id;
+DROP TABLE cooler;
+DROP TABLE pccase;
+DROP TABLE psu;
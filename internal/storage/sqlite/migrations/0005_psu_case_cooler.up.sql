@@ -0,0 +1,37 @@
+CREATE TABLE psu (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	wattage INTEGER NOT NULL,
+	efficiency TEXT NOT NULL,
+	modular INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX psu_natural_key ON psu (name);
+
+CREATE TABLE pccase (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	form_factor TEXT NOT NULL,
+	max_gpu_length INTEGER NOT NULL DEFAULT 0,
+	max_cooler_height INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX pccase_natural_key ON pccase (name);
+
+CREATE TABLE cooler (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	type TEXT NOT NULL,
+	tdp INTEGER NOT NULL,
+	height INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX cooler_natural_key ON cooler (name);
+
+ALTER TABLE pc ADD COLUMN psu_id INTEGER REFERENCES psu(id);
+ALTER TABLE pc ADD COLUMN case_id INTEGER REFERENCES pccase(id);
+ALTER TABLE pc ADD COLUMN cooler_id INTEGER REFERENCES cooler(id);
+
+CREATE INDEX pc_psu_id ON pc (psu_id);
+CREATE INDEX pc_case_id ON pc (case_id);
+CREATE INDEX pc_cooler_id ON pc (cooler_id);
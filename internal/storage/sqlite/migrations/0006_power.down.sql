@@ -0,0 +1,2 @@
+ALTER TABLE gpu DROP COLUMN board_power;
+ALTER TABLE cpu DROP COLUMN tdp;
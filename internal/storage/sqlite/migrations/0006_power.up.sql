@@ -0,0 +1,2 @@
+ALTER TABLE cpu ADD COLUMN tdp INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN board_power INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+ALTER TABLE cpu DROP COLUMN integrated_graphics;
+ALTER TABLE cpu DROP COLUMN l3_cache;
+ALTER TABLE cpu DROP COLUMN boost_clock;
+ALTER TABLE cpu DROP COLUMN architecture;
+ALTER TABLE cpu DROP COLUMN socket;
+ALTER TABLE cpu DROP COLUMN vendor;
+ALTER TABLE cpu RENAME COLUMN base_clock TO frequency;
@@ -0,0 +1,7 @@
+ALTER TABLE cpu RENAME COLUMN frequency TO base_clock;
+ALTER TABLE cpu ADD COLUMN vendor TEXT NOT NULL DEFAULT '';
+ALTER TABLE cpu ADD COLUMN socket TEXT NOT NULL DEFAULT '';
+ALTER TABLE cpu ADD COLUMN architecture TEXT NOT NULL DEFAULT '';
+ALTER TABLE cpu ADD COLUMN boost_clock INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE cpu ADD COLUMN l3_cache INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE cpu ADD COLUMN integrated_graphics INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,10 @@
+ALTER TABLE gpu DROP COLUMN usbc_outputs;
+ALTER TABLE gpu DROP COLUMN dvi_outputs;
+ALTER TABLE gpu DROP COLUMN displayport_outputs;
+ALTER TABLE gpu DROP COLUMN hdmi_outputs;
+ALTER TABLE gpu DROP COLUMN length;
+ALTER TABLE gpu DROP COLUMN pcie_lanes;
+ALTER TABLE gpu DROP COLUMN pcie_gen;
+ALTER TABLE gpu DROP COLUMN bus_width;
+ALTER TABLE gpu DROP COLUMN memory_type;
+ALTER TABLE gpu DROP COLUMN chip_vendor;
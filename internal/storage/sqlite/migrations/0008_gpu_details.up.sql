@@ -0,0 +1,19 @@
+ALTER TABLE gpu ADD COLUMN chip_vendor TEXT NOT NULL DEFAULT '';
+ALTER TABLE gpu ADD COLUMN memory_type TEXT NOT NULL DEFAULT '';
+ALTER TABLE gpu ADD COLUMN bus_width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN pcie_gen INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN pcie_lanes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN length INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN hdmi_outputs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN displayport_outputs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN dvi_outputs INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE gpu ADD COLUMN usbc_outputs INTEGER NOT NULL DEFAULT 0;
+
+-- Rows saved before chip_vendor existed often recorded the chip vendor as
+-- the manufacturer.
+UPDATE gpu SET chip_vendor = CASE UPPER(manufacturer)
+	WHEN 'NVIDIA' THEN 'NVIDIA'
+	WHEN 'AMD' THEN 'AMD'
+	WHEN 'INTEL' THEN 'Intel'
+	ELSE ''
+END;
@@ -0,0 +1,10 @@
+ALTER TABLE ram ADD COLUMN speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ram ADD COLUMN cas_latency INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ram ADD COLUMN modules INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE ram ADD COLUMN ecc INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE memory ADD COLUMN interface TEXT NOT NULL DEFAULT '';
+ALTER TABLE memory ADD COLUMN form_factor TEXT NOT NULL DEFAULT '';
+ALTER TABLE memory ADD COLUMN read_speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE memory ADD COLUMN write_speed INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE memory ADD COLUMN tbw INTEGER NOT NULL DEFAULT 0;
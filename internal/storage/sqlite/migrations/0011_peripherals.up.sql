@@ -0,0 +1,35 @@
+-- Peripherals of every type share one table, columns of the details a type
+-- does not have stay at their defaults.
+CREATE TABLE peripheral (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	type TEXT NOT NULL,
+	manufacturer TEXT NOT NULL DEFAULT '',
+	connection TEXT NOT NULL DEFAULT '',
+	diagonal REAL NOT NULL DEFAULT 0,
+	width INTEGER NOT NULL DEFAULT 0,
+	height INTEGER NOT NULL DEFAULT 0,
+	refresh_rate INTEGER NOT NULL DEFAULT 0,
+	panel TEXT NOT NULL DEFAULT '',
+	layout TEXT NOT NULL DEFAULT '',
+	switches TEXT NOT NULL DEFAULT '',
+	speed INTEGER NOT NULL DEFAULT 0,
+	ports INTEGER NOT NULL DEFAULT 0,
+	standard TEXT NOT NULL DEFAULT '',
+	bluetooth INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX peripheral_natural_key ON peripheral (manufacturer, name);
+
+CREATE TABLE pc_peripheral (
+	id INTEGER PRIMARY KEY,
+	pc_id INTEGER NOT NULL,
+	peripheral_id INTEGER NOT NULL,
+	quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+	slot TEXT NOT NULL DEFAULT '',
+	FOREIGN KEY(pc_id) REFERENCES pc(id) ON DELETE CASCADE,
+	FOREIGN KEY(peripheral_id) REFERENCES peripheral(id)
+);
+
+CREATE INDEX pc_peripheral_pc_id ON pc_peripheral (pc_id);
+CREATE INDEX pc_peripheral_peripheral_id ON pc_peripheral (peripheral_id);
@@ -0,0 +1,151 @@
+-- Ram memory types, storage types and drive interfaces saved before they
+-- were validated carry spellings like "ddr4", "DDR-4" or "ssd". Spellings
+-- are matched ignoring case, spaces, dashes, underscores and dots. Every
+-- value changed is kept in legacy_enums with its old spelling so the down
+-- migration can restore it. Values no spelling matches are left as they are
+-- and listed with a NULL value:
+--
+--	SELECT * FROM legacy_enums WHERE value IS NULL;
+CREATE TABLE legacy_enums (
+	table_name TEXT NOT NULL,
+	column_name TEXT NOT NULL,
+	row_id INTEGER NOT NULL,
+	legacy_value TEXT NOT NULL,
+	value TEXT,
+	PRIMARY KEY (table_name, column_name, row_id)
+);
+
+CREATE TABLE enum_spellings (
+	column_name TEXT NOT NULL,
+	spelling TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (column_name, spelling)
+);
+
+INSERT INTO enum_spellings (column_name, spelling, value) VALUES
+	('memory_type', 'DDR3', 'DDR3'),
+	('memory_type', 'DDR3SDRAM', 'DDR3'),
+	('memory_type', 'DDR4', 'DDR4'),
+	('memory_type', 'DDR4SDRAM', 'DDR4'),
+	('memory_type', 'DDR5', 'DDR5'),
+	('memory_type', 'DDR5SDRAM', 'DDR5'),
+	('memory_type', 'DDR4ECC', 'DDR4 ECC'),
+	('memory_type', 'ECCDDR4', 'DDR4 ECC'),
+	('memory_type', 'DDR5ECC', 'DDR5 ECC'),
+	('memory_type', 'ECCDDR5', 'DDR5 ECC'),
+	('memory_type', 'LPDDR4', 'LPDDR4'),
+	('memory_type', 'LPDDR4X', 'LPDDR4X'),
+	('memory_type', 'LPDDR5', 'LPDDR5'),
+	('memory_type', 'LPDDR5X', 'LPDDR5X'),
+	('type', 'SSD', 'SSD'),
+	('type', 'SOLIDSTATE', 'SSD'),
+	('type', 'SOLIDSTATEDRIVE', 'SSD'),
+	('type', 'HDD', 'HDD'),
+	('type', 'HARDDISK', 'HDD'),
+	('type', 'HARDDRIVE', 'HDD'),
+	('type', 'HARDDISKDRIVE', 'HDD'),
+	('type', 'SATASSD', 'SATA SSD'),
+	('type', 'SSDSATA', 'SATA SSD'),
+	('type', 'NVME', 'NVMe'),
+	('type', 'NVMESSD', 'NVMe'),
+	('type', 'M2NVME', 'NVMe'),
+	('interface', 'SATA', 'SATA'),
+	('interface', 'SATA3', 'SATA'),
+	('interface', 'SATAIII', 'SATA'),
+	('interface', 'SATA6GB/S', 'SATA'),
+	('interface', 'PCIE3', 'PCIe 3.0'),
+	('interface', 'PCIE30', 'PCIe 3.0'),
+	('interface', 'PCIEGEN3', 'PCIe 3.0'),
+	('interface', 'PCIE3X4', 'PCIe 3.0'),
+	('interface', 'PCIE30X4', 'PCIe 3.0'),
+	('interface', 'PCIE4', 'PCIe 4.0'),
+	('interface', 'PCIE40', 'PCIe 4.0'),
+	('interface', 'PCIEGEN4', 'PCIe 4.0'),
+	('interface', 'PCIE4X4', 'PCIe 4.0'),
+	('interface', 'PCIE40X4', 'PCIe 4.0'),
+	('interface', 'PCIE5', 'PCIe 5.0'),
+	('interface', 'PCIE50', 'PCIe 5.0'),
+	('interface', 'PCIEGEN5', 'PCIe 5.0'),
+	('interface', 'PCIE5X4', 'PCIe 5.0'),
+	('interface', 'PCIE50X4', 'PCIe 5.0');
+
+INSERT INTO legacy_enums (table_name, column_name, row_id, legacy_value, value)
+SELECT 'ram', 'memory_type', id, memory_type, (
+	SELECT value FROM enum_spellings
+	WHERE column_name = 'memory_type'
+		AND spelling = upper(replace(replace(replace(replace(trim(memory_type), ' ', ''), '-', ''), '_', ''), '.', ''))
+)
+FROM ram WHERE memory_type NOT IN (SELECT value FROM enum_spellings WHERE column_name = 'memory_type');
+
+INSERT INTO legacy_enums (table_name, column_name, row_id, legacy_value, value)
+SELECT 'memory', 'type', id, type, (
+	SELECT value FROM enum_spellings
+	WHERE column_name = 'type'
+		AND spelling = upper(replace(replace(replace(replace(trim(type), ' ', ''), '-', ''), '_', ''), '.', ''))
+)
+FROM memory WHERE type NOT IN (SELECT value FROM enum_spellings WHERE column_name = 'type');
+
+-- An empty interface is unknown, not a spelling.
+INSERT INTO legacy_enums (table_name, column_name, row_id, legacy_value, value)
+SELECT 'memory', 'interface', id, interface, (
+	SELECT value FROM enum_spellings
+	WHERE column_name = 'interface'
+		AND spelling = upper(replace(replace(replace(replace(trim(interface), ' ', ''), '-', ''), '_', ''), '.', ''))
+)
+FROM memory WHERE interface <> '' AND interface NOT IN (SELECT value FROM enum_spellings WHERE column_name = 'interface');
+
+DROP TABLE enum_spellings;
+
+-- Rows spelling the same part differently collide on the natural keys once
+-- normalized. They are dropped here and the duplicates merged as in 0002.
+DROP INDEX ram_natural_key;
+DROP INDEX memory_natural_key;
+
+UPDATE ram SET memory_type = (
+	SELECT value FROM legacy_enums
+	WHERE table_name = 'ram' AND column_name = 'memory_type' AND row_id = ram.id
+) WHERE id IN (
+	SELECT row_id FROM legacy_enums
+	WHERE table_name = 'ram' AND column_name = 'memory_type' AND value IS NOT NULL
+);
+UPDATE memory SET type = (
+	SELECT value FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'type' AND row_id = memory.id
+) WHERE id IN (
+	SELECT row_id FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'type' AND value IS NOT NULL
+);
+UPDATE memory SET interface = (
+	SELECT value FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'interface' AND row_id = memory.id
+) WHERE id IN (
+	SELECT row_id FROM legacy_enums
+	WHERE table_name = 'memory' AND column_name = 'interface' AND value IS NOT NULL
+);
+
+-- ECC memory types imply ECC, as when saving.
+UPDATE ram SET ecc = TRUE WHERE memory_type IN ('DDR4 ECC', 'DDR5 ECC');
+
+UPDATE pc_ram SET ram_id = (
+	SELECT MIN(d.id) FROM ram r JOIN ram d
+		ON d.name = r.name AND d.memory_type = r.memory_type AND d.capacity = r.capacity
+	WHERE r.id = pc_ram.ram_id
+);
+DELETE FROM ram WHERE id NOT IN (SELECT MIN(id) FROM ram GROUP BY name, memory_type, capacity);
+
+UPDATE pc_memory SET memory_id = (
+	SELECT MIN(d.id) FROM memory m JOIN memory d
+		ON d.name = m.name AND d.capacity = m.capacity AND d.type = m.type
+	WHERE m.id = pc_memory.memory_id
+);
+DELETE FROM memory WHERE id NOT IN (SELECT MIN(id) FROM memory GROUP BY name, capacity, type);
+
+DELETE FROM legacy_enums WHERE
+	(table_name = 'ram' AND row_id NOT IN (SELECT id FROM ram)) OR
+	(table_name = 'memory' AND row_id NOT IN (SELECT id FROM memory));
+DELETE FROM legacy_units WHERE
+	(table_name = 'ram' AND row_id NOT IN (SELECT id FROM ram)) OR
+	(table_name = 'memory' AND row_id NOT IN (SELECT id FROM memory));
+
+CREATE UNIQUE INDEX ram_natural_key ON ram (name, memory_type, capacity);
+CREATE UNIQUE INDEX memory_natural_key ON memory (name, capacity, type);
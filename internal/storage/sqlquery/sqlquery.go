@@ -0,0 +1,217 @@
+// Package sqlquery builds the filtered, keyset paginated SELECTs behind the
+// List* methods of the SQL backends. What differs between databases is
+// described by a Dialect.
+package sqlquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// Dialect is the SQL a backend speaks.
+type Dialect struct {
+	// Placeholder returns the placeholder of the n-th bound argument,
+	// counting from 1.
+	Placeholder func(n int) string
+	// ILike is the case insensitive LIKE operator.
+	ILike string
+	// IsUnique reports whether err is a unique constraint violation.
+	IsUnique func(err error) bool
+	// IsForeignKey reports whether err is a foreign key violation.
+	IsForeignKey func(err error) bool
+}
+
+// Rebind replaces the ? placeholders of query with those of d. Queries must
+// not hold a ? anywhere else.
+func (d Dialect) Rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteString(d.Placeholder(n))
+	}
+
+	return b.String()
+}
+
+// Query collects WHERE conditions and their arguments for a SELECT.
+// Column names always come from code, values are always bound as arguments.
+type Query struct {
+	dialect Dialect
+	conds   []string
+	args    []any
+}
+
+func New(d Dialect) *Query {
+	return &Query{dialect: d}
+}
+
+// Args returns the arguments bound so far.
+func (q *Query) Args() []any {
+	return q.args
+}
+
+// arg binds v and returns its placeholder.
+func (q *Query) arg(v any) string {
+	q.args = append(q.args, v)
+	return q.dialect.Placeholder(len(q.args))
+}
+
+func (q *Query) where(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+// Contains matches col containing value, ignoring case.
+func (q *Query) Contains(col, value string) {
+	if value == "" {
+		return
+	}
+	value = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+	q.where(col + " " + q.dialect.ILike + " '%' || " + q.arg(value) + ` || '%' ESCAPE '\'`)
+}
+
+// EqualFold matches col equal to value, ignoring case.
+func (q *Query) EqualFold(col, value string) {
+	if value == "" {
+		return
+	}
+	q.where("lower(" + col + ") = lower(" + q.arg(value) + ")")
+}
+
+func (q *Query) Equal(col string, value *int64) {
+	if value == nil {
+		return
+	}
+	q.where(col + " = " + q.arg(*value))
+}
+
+// HasRow matches pcs with a row in the junction table whose column equals
+// id.
+func (q *Query) HasRow(table, column string, id *int64) {
+	if id == nil {
+		return
+	}
+	q.where("EXISTS (SELECT 1 FROM " + table + " WHERE " + table + ".pc_id = pc.id AND " + table + "." + column + " = " + q.arg(*id) + ")")
+}
+
+func (q *Query) Int64(col string, f storage.Int64Filter) {
+	q.Equal(col, f.Eq)
+	if f.GTE != nil {
+		q.where(col + " >= " + q.arg(*f.GTE))
+	}
+	if f.LTE != nil {
+		q.where(col + " <= " + q.arg(*f.LTE))
+	}
+}
+
+// Build returns the full statement for selecting columns from table with
+// the collected conditions plus keyset pagination. The sort column is
+// appended to the selected columns so the caller can build the next cursor.
+func (q *Query) Build(table, columns string, sortColumns map[string]string, opts storage.ListOptions) (string, error) {
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = "id"
+	}
+	col, ok := sortColumns[sortBy]
+	if !ok {
+		return "", storage.ErrInvalidSort
+	}
+
+	cmp, dir := ">", "ASC"
+	if opts.Desc {
+		cmp, dir = "<", "DESC"
+	}
+
+	if opts.Cursor != "" {
+		c, err := storage.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return "", err
+		}
+		if col == "id" {
+			q.where("id " + cmp + " " + q.arg(c.ID))
+		} else {
+			q.where(fmt.Sprintf("(%[1]s %[2]s %[3]s OR (%[1]s = %[4]s AND id %[2]s %[5]s))", col, cmp, q.arg(c.Value), q.arg(c.Value), q.arg(c.ID)))
+		}
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s FROM %s", columns, col, table)
+	if len(q.conds) > 0 {
+		query += " WHERE " + strings.Join(q.conds, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, id %[2]s LIMIT %[3]s", col, dir, q.arg(opts.PageLimit()+1))
+
+	return query, nil
+}
+
+// Querier runs a SELECT, *sql.DB and *sql.Tx are both one.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Table describes how rows of a table are listed into T.
+type Table[T any] struct {
+	Name string
+	// Columns are the selected columns, id first.
+	Columns     string
+	SortColumns map[string]string
+	// Scan returns the scan destinations of Columns for row, the first one
+	// being its id, and an optional func run once the row is scanned.
+	Scan func(row *T) ([]any, func())
+}
+
+// List returns a page of the rows of t matching q and the cursor of the
+// following page, empty on the last one.
+func List[T any](ctx context.Context, db Querier, q *Query, t Table[T], opts storage.ListOptions) ([]T, string, error) {
+	query, err := q.Build(t.Name, t.Columns, t.SortColumns, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows, err := db.QueryContext(ctx, query, q.Args()...)
+	if err != nil {
+		return nil, "", fmt.Errorf("execute statement: %w", err)
+	}
+	defer rows.Close()
+
+	var res []T
+	var ids []int64
+	var sortValues []any
+	for rows.Next() {
+		var row T
+		var sortValue any
+		dest, done := t.Scan(&row)
+		if err := rows.Scan(append(dest, &sortValue)...); err != nil {
+			return nil, "", fmt.Errorf("scan row: %w", err)
+		}
+		if done != nil {
+			done()
+		}
+		res = append(res, row)
+		ids = append(ids, *dest[0].(*int64))
+		sortValues = append(sortValues, sortValue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	limit := opts.PageLimit()
+	if len(res) <= limit {
+		return res, "", nil
+	}
+
+	next := storage.EncodeCursor(storage.Cursor{Value: sortValues[limit-1], ID: ids[limit-1]})
+
+	return res[:limit], next, nil
+}
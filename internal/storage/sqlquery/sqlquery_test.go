@@ -0,0 +1,31 @@
+package sqlquery
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	numbered := Dialect{Placeholder: func(n int) string { return "$" + strconv.Itoa(n) }}
+	question := Dialect{Placeholder: func(int) string { return "?" }}
+
+	tests := []struct {
+		name  string
+		d     Dialect
+		query string
+		want  string
+	}{
+		{"numbered", numbered, "SELECT id FROM ram WHERE name = ? AND capacity = ?", "SELECT id FROM ram WHERE name = $1 AND capacity = $2"},
+		{"question marks kept", question, "DELETE FROM ram WHERE id = ?", "DELETE FROM ram WHERE id = ?"},
+		{"no placeholders", numbered, "SELECT 1", "SELECT 1"},
+		{"already numbered", numbered, "SELECT id FROM ram WHERE id = $1", "SELECT id FROM ram WHERE id = $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Rebind(tt.query); got != tt.want {
+				t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// SaveBuild creates the new components of the build and the pc itself in a
+// single transaction, nothing is left behind if any insert fails.
+func (s *Storage) SaveBuild(ctx context.Context, b pc.Build) (*pc.PC, error) {
+	const op = "storage.sqlstore.SaveBuild"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	res, err := storage.SaveBuild(ctx, buildTx{s.on(tx)}, b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return &res, nil
+}
+
+// buildTx is a storage.BuildTx saving through q.
+type buildTx struct {
+	q conn
+}
+
+func (t buildTx) SaveCPU(ctx context.Context, c cpu.CPU) (int64, error) {
+	return saveCPU(ctx, t.q, c)
+}
+
+func (t buildTx) SaveRAM(ctx context.Context, r ram.RAM) (int64, error) {
+	return saveRAM(ctx, t.q, r)
+}
+
+func (t buildTx) SaveGPU(ctx context.Context, g gpu.GPU) (int64, error) {
+	return saveGPU(ctx, t.q, g)
+}
+
+func (t buildTx) SaveMemory(ctx context.Context, m memory.Memory) (int64, error) {
+	return saveMemory(ctx, t.q, m)
+}
+
+func (t buildTx) SaveMotherboard(ctx context.Context, m motherboard.Motherboard) (int64, error) {
+	return saveMotherboard(ctx, t.q, m)
+}
+
+func (t buildTx) SavePSU(ctx context.Context, p psu.PSU) (int64, error) {
+	return savePSU(ctx, t.q, p)
+}
+
+func (t buildTx) SaveCase(ctx context.Context, c pccase.Case) (int64, error) {
+	return saveCase(ctx, t.q, c)
+}
+
+func (t buildTx) SaveCooler(ctx context.Context, c cooler.Cooler) (int64, error) {
+	return saveCooler(ctx, t.q, c)
+}
+
+func (t buildTx) SavePC(ctx context.Context, p pc.PC) (int64, error) {
+	return savePC(ctx, t.q, p)
+}
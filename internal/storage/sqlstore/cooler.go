@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// coolerColumns are the cooler columns besides id, in coolerFields order.
+const coolerColumns = "name, type, tdp, height"
+
+// coolerFields returns scan destinations for coolerColumns.
+func coolerFields(c *cooler.Cooler) []any {
+	return []any{&c.Name, &c.Type, &c.TDP, &c.Height}
+}
+
+func (s *Storage) SaveCooler(ctx context.Context, c cooler.Cooler) (int64, error) {
+	return saveCooler(ctx, s.q, c)
+}
+
+func saveCooler(ctx context.Context, q conn, c cooler.Cooler) (int64, error) {
+	const op = "storage.sqlstore.SaveCooler"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO cooler ("+coolerColumns+") VALUES (?, ?, ?, ?) ON CONFLICT (name) DO NOTHING RETURNING id",
+		c.Name, c.Type, c.TDP, c.Height,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx, "SELECT id FROM cooler WHERE name = ?", c.Name).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing cooler: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrCoolerAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetCooler(ctx context.Context, id int64) (*cooler.Cooler, error) {
+	return getCooler(ctx, s.q, id)
+}
+
+func getCooler(ctx context.Context, q conn, id int64) (*cooler.Cooler, error) {
+	const op = "storage.sqlstore.GetCooler"
+
+	res := cooler.Cooler{ID: id}
+	err := q.QueryRowContext(ctx,
+		"SELECT "+coolerColumns+" FROM cooler WHERE id = ?", id,
+	).Scan(coolerFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrCoolerNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+
+}
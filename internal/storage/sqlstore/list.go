@@ -0,0 +1,194 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/sqlquery"
+)
+
+// sortable columns per table, keyed by the public field name
+var (
+	pcSortColumns     = map[string]string{"id": "id", "name": "name"}
+	ramSortColumns    = map[string]string{"id": "id", "name": "name", "memory_type": "memory_type", "capacity": "capacity", "speed": "speed"}
+	cpuSortColumns    = map[string]string{"id": "id", "name": "name", "cores": "cores", "threads": "threads", "base_clock": "base_clock", "boost_clock": "boost_clock", "tdp": "tdp"}
+	gpuSortColumns    = map[string]string{"id": "id", "name": "name", "manufacturer": "manufacturer", "memory": "memory", "frequency": "frequency", "board_power": "board_power", "length": "length"}
+	memorySortColumns = map[string]string{"id": "id", "name": "name", "capacity": "capacity", "storage_type": "type", "read_speed": "read_speed", "write_speed": "write_speed"}
+
+	peripheralSortColumns = map[string]string{"id": "id", "name": "name", "type": "type", "manufacturer": "manufacturer"}
+)
+
+// hasPart matches pcs that have the component with the given id in t.
+func hasPart(q *sqlquery.Query, t partTable, id *int64) {
+	q.HasRow(t.table, t.column, id)
+}
+
+func (s *Storage) ListPC(ctx context.Context, filter storage.PCFilter, opts storage.ListOptions) ([]pc.PC, string, error) {
+	const op = "storage.sqlstore.ListPC"
+
+	q := sqlquery.New(s.q.dialect)
+	q.Contains("name", filter.Name)
+	q.Equal("cpu_id", filter.CPUID)
+	hasPart(q, ramParts, filter.RAMID)
+	hasPart(q, gpuParts, filter.GPUID)
+	hasPart(q, memoryParts, filter.MemoryID)
+	q.Equal("motherboard_id", filter.MotherboardID)
+	q.Equal("psu_id", filter.PSUID)
+	q.Equal("case_id", filter.CaseID)
+	q.Equal("cooler_id", filter.CoolerID)
+	hasPart(q, peripheralParts, filter.PeripheralID)
+
+	res, next, err := sqlquery.List(ctx, s.q, q, sqlquery.Table[pc.PC]{
+		Name:        "pc",
+		Columns:     "id, name, cpu_id, motherboard_id, psu_id, case_id, cooler_id",
+		SortColumns: pcSortColumns,
+		Scan: func(p *pc.PC) ([]any, func()) {
+			refs, setRefs := scanRefs(p)
+			return append([]any{&p.ID, &p.Name, &p.CPUID}, refs...), setRefs
+		},
+	}, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := loadParts(ctx, s.q, res); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, next, nil
+}
+
+func (s *Storage) ListRAM(ctx context.Context, filter storage.RAMFilter, opts storage.ListOptions) ([]ram.RAM, string, error) {
+	const op = "storage.sqlstore.ListRAM"
+
+	q := sqlquery.New(s.q.dialect)
+	q.Contains("name", filter.Name)
+	q.EqualFold("memory_type", filter.MemoryType)
+	q.Int64("capacity", filter.Capacity)
+	q.Int64("speed", filter.Speed)
+
+	res, next, err := sqlquery.List(ctx, s.q, q, sqlquery.Table[ram.RAM]{
+		Name:        "ram",
+		Columns:     "id, " + ramColumns,
+		SortColumns: ramSortColumns,
+		Scan: func(r *ram.RAM) ([]any, func()) {
+			return append([]any{&r.ID}, ramFields(r)...), nil
+		},
+	}, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, next, nil
+}
+
+func (s *Storage) ListCPU(ctx context.Context, filter storage.CPUFilter, opts storage.ListOptions) ([]cpu.CPU, string, error) {
+	const op = "storage.sqlstore.ListCPU"
+
+	q := sqlquery.New(s.q.dialect)
+	q.Contains("name", filter.Name)
+	q.Int64("cores", filter.Cores)
+	q.Int64("threads", filter.Threads)
+	q.Int64("base_clock", filter.BaseClock)
+	q.Int64("tdp", filter.TDP)
+	q.EqualFold("vendor", filter.Vendor)
+	q.EqualFold("socket", filter.Socket)
+
+	res, next, err := sqlquery.List(ctx, s.q, q, sqlquery.Table[cpu.CPU]{
+		Name:        "cpu",
+		Columns:     "id, " + cpuColumns,
+		SortColumns: cpuSortColumns,
+		Scan: func(c *cpu.CPU) ([]any, func()) {
+			return append([]any{&c.ID}, cpuFields(c)...), nil
+		},
+	}, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, next, nil
+}
+
+func (s *Storage) ListGPU(ctx context.Context, filter storage.GPUFilter, opts storage.ListOptions) ([]gpu.GPU, string, error) {
+	const op = "storage.sqlstore.ListGPU"
+
+	q := sqlquery.New(s.q.dialect)
+	q.Contains("name", filter.Name)
+	q.EqualFold("chip_vendor", filter.ChipVendor)
+	q.EqualFold("manufacturer", filter.Manufacturer)
+	q.EqualFold("memory_type", filter.MemoryType)
+	q.Int64("memory", filter.Memory)
+	q.Int64("frequency", filter.Frequency)
+	q.Int64("length", filter.Length)
+
+	res, next, err := sqlquery.List(ctx, s.q, q, sqlquery.Table[gpu.GPU]{
+		Name:        "gpu",
+		Columns:     "id, " + gpuColumns,
+		SortColumns: gpuSortColumns,
+		Scan: func(g *gpu.GPU) ([]any, func()) {
+			return append([]any{&g.ID}, gpuFields(g)...), nil
+		},
+	}, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, next, nil
+}
+
+func (s *Storage) ListMemory(ctx context.Context, filter storage.MemoryFilter, opts storage.ListOptions) ([]memory.Memory, string, error) {
+	const op = "storage.sqlstore.ListMemory"
+
+	q := sqlquery.New(s.q.dialect)
+	q.Contains("name", filter.Name)
+	q.EqualFold("type", filter.StorageType)
+	q.EqualFold("interface", filter.Interface)
+	q.EqualFold("form_factor", filter.FormFactor)
+	q.Int64("capacity", filter.Capacity)
+	q.Int64("read_speed", filter.ReadSpeed)
+
+	res, next, err := sqlquery.List(ctx, s.q, q, sqlquery.Table[memory.Memory]{
+		Name:        "memory",
+		Columns:     "id, " + memoryColumns,
+		SortColumns: memorySortColumns,
+		Scan: func(m *memory.Memory) ([]any, func()) {
+			return append([]any{&m.ID}, memoryFields(m)...), nil
+		},
+	}, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, next, nil
+}
+
+func (s *Storage) ListPeripheral(ctx context.Context, filter storage.PeripheralFilter, opts storage.ListOptions) ([]peripheral.Peripheral, string, error) {
+	const op = "storage.sqlstore.ListPeripheral"
+
+	q := sqlquery.New(s.q.dialect)
+	q.Contains("name", filter.Name)
+	q.EqualFold("type", filter.Type)
+	q.EqualFold("manufacturer", filter.Manufacturer)
+	q.EqualFold("connection", filter.Connection)
+
+	res, next, err := sqlquery.List(ctx, s.q, q, sqlquery.Table[peripheral.Peripheral]{
+		Name:        "peripheral",
+		Columns:     "id, " + peripheralColumns,
+		SortColumns: peripheralSortColumns,
+		Scan: func(p *peripheral.Peripheral) ([]any, func()) {
+			return append([]any{&p.ID}, peripheralFields(p)...), func() { *p = p.WithOwnDetails() }
+		},
+	}, opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, next, nil
+}
@@ -0,0 +1,71 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// motherboardColumns are the motherboard columns besides id, in motherboardFields order.
+const motherboardColumns = "name, socket, chipset, form_factor, memory_type, ram_slots, max_memory, m2_slots, sata_ports"
+
+// motherboardFields returns scan destinations for motherboardColumns.
+func motherboardFields(m *motherboard.Motherboard) []any {
+	return []any{
+		&m.Name, &m.Socket, &m.Chipset, &m.FormFactor, &m.MemoryType,
+		&m.RAMSlots, &m.MaxMemory, &m.M2Slots, &m.SATAPorts,
+	}
+}
+
+func (s *Storage) SaveMotherboard(ctx context.Context, m motherboard.Motherboard) (int64, error) {
+	return saveMotherboard(ctx, s.q, m)
+}
+
+func saveMotherboard(ctx context.Context, q conn, m motherboard.Motherboard) (int64, error) {
+	const op = "storage.sqlstore.SaveMotherboard"
+
+	var id int64
+	err := q.QueryRowContext(ctx, `
+		INSERT INTO motherboard (`+motherboardColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (name) DO NOTHING RETURNING id
+	`, m.Name, m.Socket, m.Chipset, m.FormFactor, m.MemoryType, m.RAMSlots, m.MaxMemory, m.M2Slots, m.SATAPorts).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx, "SELECT id FROM motherboard WHERE name = ?", m.Name).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing motherboard: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrMotherboardAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetMotherboard(ctx context.Context, id int64) (*motherboard.Motherboard, error) {
+	return getMotherboard(ctx, s.q, id)
+}
+
+func getMotherboard(ctx context.Context, q conn, id int64) (*motherboard.Motherboard, error) {
+	const op = "storage.sqlstore.GetMotherboard"
+
+	res := motherboard.Motherboard{ID: id}
+	err := q.QueryRowContext(ctx,
+		"SELECT "+motherboardColumns+" FROM motherboard WHERE id = ?", id,
+	).Scan(motherboardFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrMotherboardNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+
+}
@@ -0,0 +1,186 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// partTable describes a junction table linking pcs to one kind of component.
+// Table and column names always come from code.
+type partTable struct {
+	table     string
+	column    string
+	component string
+	parts     func(p *pc.PC) *[]pc.Part
+}
+
+var (
+	ramParts        = partTable{"pc_ram", "ram_id", "ram", func(p *pc.PC) *[]pc.Part { return &p.RAM }}
+	gpuParts        = partTable{"pc_gpu", "gpu_id", "gpu", func(p *pc.PC) *[]pc.Part { return &p.GPU }}
+	memoryParts     = partTable{"pc_memory", "memory_id", "memory", func(p *pc.PC) *[]pc.Part { return &p.Memory }}
+	peripheralParts = partTable{"pc_peripheral", "peripheral_id", "peripheral", func(p *pc.PC) *[]pc.Part { return &p.Peripherals }}
+
+	partTables = []partTable{ramParts, gpuParts, memoryParts, peripheralParts}
+)
+
+// pcRef describes a nullable pc column referencing an optional component.
+// Queries list these columns in pcRefs order.
+type pcRef struct {
+	column    string
+	table     string
+	component string
+	id        func(p *pc.PC) *int64
+}
+
+var pcRefs = []pcRef{
+	{"motherboard_id", "motherboard", "motherboard", func(p *pc.PC) *int64 { return &p.MotherboardID }},
+	{"psu_id", "psu", "psu", func(p *pc.PC) *int64 { return &p.PSUID }},
+	{"case_id", "pccase", "case", func(p *pc.PC) *int64 { return &p.CaseID }},
+	{"cooler_id", "cooler", "cooler", func(p *pc.PC) *int64 { return &p.CoolerID }},
+}
+
+// refArgs returns the optional component ids of p in pcRefs order.
+func refArgs(p pc.PC) []any {
+	args := make([]any, len(pcRefs))
+	for i, r := range pcRefs {
+		args[i] = nullID(*r.id(&p))
+	}
+
+	return args
+}
+
+// scanRefs returns scan destinations for the refColumns of a row and a func
+// storing the scanned ids in p once the row is scanned.
+func scanRefs(p *pc.PC) ([]any, func()) {
+	ids := make([]sql.NullInt64, len(pcRefs))
+	dest := make([]any, len(ids))
+	for i := range ids {
+		dest[i] = &ids[i]
+	}
+
+	return dest, func() {
+		for i, r := range pcRefs {
+			*r.id(p) = ids[i].Int64
+		}
+	}
+}
+
+// checkComponents returns a ComponentNotFoundError for the first component of
+// the pc that does not exist.
+func checkComponents(ctx context.Context, q conn, p pc.PC) error {
+	ok, err := exists(ctx, q, "cpu", p.CPUID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &storage.ComponentNotFoundError{Component: "cpu", ID: p.CPUID}
+	}
+
+	for _, t := range partTables {
+		for _, part := range *t.parts(&p) {
+			ok, err := exists(ctx, q, t.component, part.ID)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return &storage.ComponentNotFoundError{Component: t.component, ID: part.ID}
+			}
+		}
+	}
+
+	for _, r := range pcRefs {
+		id := *r.id(&p)
+		if id == 0 {
+			continue
+		}
+		ok, err := exists(ctx, q, r.table, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &storage.ComponentNotFoundError{Component: r.component, ID: id}
+		}
+	}
+
+	return nil
+}
+
+// nullID stores a zero id of an optional component as NULL.
+func nullID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
+
+// insertParts links all parts of p to the pc with id pcID.
+func insertParts(ctx context.Context, q conn, pcID int64, p pc.PC) error {
+	for _, t := range partTables {
+		for _, part := range *t.parts(&p) {
+			_, err := q.ExecContext(ctx,
+				"INSERT INTO "+t.table+" (pc_id, "+t.column+", quantity, slot) VALUES (?, ?, ?, ?)",
+				pcID, part.ID, part.Quantity, part.Slot,
+			)
+			if err != nil {
+				return fmt.Errorf("insert into %s: %w", t.table, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// deleteParts unlinks all parts from the pc with id pcID.
+func deleteParts(ctx context.Context, q conn, pcID int64) error {
+	for _, t := range partTables {
+		if _, err := q.ExecContext(ctx, "DELETE FROM "+t.table+" WHERE pc_id = ?", pcID); err != nil {
+			return fmt.Errorf("delete from %s: %w", t.table, err)
+		}
+	}
+
+	return nil
+}
+
+// loadParts fills in the parts of every pc with one query per junction table.
+func loadParts(ctx context.Context, q conn, pcs []pc.PC) error {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	index := make(map[int64]int, len(pcs))
+	args := make([]any, len(pcs))
+	for i, p := range pcs {
+		index[p.ID] = i
+		args[i] = p.ID
+	}
+	in := strings.Repeat("?, ", len(pcs)-1) + "?"
+
+	for _, t := range partTables {
+		rows, err := q.QueryContext(ctx,
+			"SELECT pc_id, "+t.column+", quantity, slot FROM "+t.table+" WHERE pc_id IN ("+in+") ORDER BY id",
+			args...,
+		)
+		if err != nil {
+			return fmt.Errorf("select from %s: %w", t.table, err)
+		}
+
+		for rows.Next() {
+			var pcID int64
+			var part pc.Part
+			if err := rows.Scan(&pcID, &part.ID, &part.Quantity, &part.Slot); err != nil {
+				rows.Close()
+				return fmt.Errorf("scan %s: %w", t.table, err)
+			}
+			parts := t.parts(&pcs[index[pcID]])
+			*parts = append(*parts, part)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("select from %s: %w", t.table, err)
+		}
+	}
+
+	return nil
+}
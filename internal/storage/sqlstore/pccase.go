@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// caseColumns are the case columns besides id, in caseFields order.
+const caseColumns = "name, form_factor, max_gpu_length, max_cooler_height"
+
+// caseFields returns scan destinations for caseColumns.
+func caseFields(c *pccase.Case) []any {
+	return []any{&c.Name, &c.FormFactor, &c.MaxGPULength, &c.MaxCoolerHeight}
+}
+
+func (s *Storage) SaveCase(ctx context.Context, c pccase.Case) (int64, error) {
+	return saveCase(ctx, s.q, c)
+}
+
+func saveCase(ctx context.Context, q conn, c pccase.Case) (int64, error) {
+	const op = "storage.sqlstore.SaveCase"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO pccase ("+caseColumns+") VALUES (?, ?, ?, ?) ON CONFLICT (name) DO NOTHING RETURNING id",
+		c.Name, c.FormFactor, c.MaxGPULength, c.MaxCoolerHeight,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx, "SELECT id FROM pccase WHERE name = ?", c.Name).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing case: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrCaseAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetCase(ctx context.Context, id int64) (*pccase.Case, error) {
+	return getCase(ctx, s.q, id)
+}
+
+func getCase(ctx context.Context, q conn, id int64) (*pccase.Case, error) {
+	const op = "storage.sqlstore.GetCase"
+
+	res := pccase.Case{ID: id}
+	err := q.QueryRowContext(ctx,
+		"SELECT "+caseColumns+" FROM pccase WHERE id = ?", id,
+	).Scan(caseFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrCaseNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+
+}
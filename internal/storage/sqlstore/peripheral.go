@@ -0,0 +1,116 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// peripheralColumns are the peripheral columns besides id, in
+// peripheralFields order.
+const peripheralColumns = "name, type, manufacturer, connection, diagonal, width, height, refresh_rate, panel, layout, switches, speed, ports, standard, bluetooth"
+
+// peripheralFields returns scan destinations for peripheralColumns. It sets
+// the details of every type, call WithOwnDetails once the row is scanned.
+func peripheralFields(p *peripheral.Peripheral) []any {
+	*p = p.WithAllDetails()
+	return []any{
+		&p.Name, &p.Type, &p.Manufacturer, &p.Connection,
+		&p.Monitor.Diagonal, &p.Monitor.Width, &p.Monitor.Height, &p.Monitor.RefreshRate, &p.Monitor.Panel,
+		&p.Keyboard.Layout, &p.Keyboard.Switches,
+		&p.Network.Speed, &p.Network.Ports, &p.Network.Standard, &p.Network.Bluetooth,
+	}
+}
+
+// peripheralArgs returns the values of peripheralColumns for p.
+func peripheralArgs(p peripheral.Peripheral) []any {
+	p = p.WithAllDetails()
+	return []any{
+		p.Name, p.Type, p.Manufacturer, p.Connection,
+		p.Monitor.Diagonal, p.Monitor.Width, p.Monitor.Height, p.Monitor.RefreshRate, p.Monitor.Panel,
+		p.Keyboard.Layout, p.Keyboard.Switches,
+		p.Network.Speed, p.Network.Ports, p.Network.Standard, p.Network.Bluetooth,
+	}
+}
+
+func (s *Storage) SavePeripheral(ctx context.Context, p peripheral.Peripheral) (int64, error) {
+	const op = "storage.sqlstore.SavePeripheral"
+
+	var id int64
+	err := s.q.QueryRowContext(ctx, `
+		INSERT INTO peripheral (`+peripheralColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (manufacturer, name) DO NOTHING RETURNING id
+	`, peripheralArgs(p)...).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := s.q.QueryRowContext(ctx,
+			"SELECT id FROM peripheral WHERE manufacturer = ? AND name = ?",
+			p.Manufacturer, p.Name,
+		).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing peripheral: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrPeripheralAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetPeripheral(ctx context.Context, id int64) (*peripheral.Peripheral, error) {
+	const op = "storage.sqlstore.GetPeripheral"
+
+	res := peripheral.Peripheral{ID: id}
+	err := s.q.QueryRowContext(ctx,
+		"SELECT "+peripheralColumns+" FROM peripheral WHERE id = ?", id,
+	).Scan(peripheralFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrPeripheralNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	res = res.WithOwnDetails()
+
+	return &res, nil
+}
+
+func (s *Storage) UpdatePeripheral(ctx context.Context, p peripheral.Peripheral) error {
+	const op = "storage.sqlstore.UpdatePeripheral"
+
+	res, err := s.q.ExecContext(ctx, `
+		UPDATE peripheral SET name = ?, type = ?, manufacturer = ?, connection = ?,
+			diagonal = ?, width = ?, height = ?, refresh_rate = ?, panel = ?,
+			layout = ?, switches = ?,
+			speed = ?, ports = ?, standard = ?, bluetooth = ?
+		WHERE id = ?
+	`, append(peripheralArgs(p), p.ID)...)
+	if s.q.dialect.IsUnique(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrPeripheralAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrPeripheralNotFound)
+}
+
+func (s *Storage) DeletePeripheral(ctx context.Context, id int64) error {
+	const op = "storage.sqlstore.DeletePeripheral"
+
+	res, err := s.q.ExecContext(ctx, "DELETE FROM peripheral WHERE id = ?", id)
+	if s.q.dialect.IsForeignKey(err) {
+		return storage.ErrPeripheralInUse
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrPeripheralNotFound)
+}
@@ -0,0 +1,67 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// psuColumns are the psu columns besides id, in psuFields order.
+const psuColumns = "name, wattage, efficiency, modular"
+
+// psuFields returns scan destinations for psuColumns.
+func psuFields(p *psu.PSU) []any {
+	return []any{&p.Name, &p.Wattage, &p.Efficiency, &p.Modular}
+}
+
+func (s *Storage) SavePSU(ctx context.Context, p psu.PSU) (int64, error) {
+	return savePSU(ctx, s.q, p)
+}
+
+func savePSU(ctx context.Context, q conn, p psu.PSU) (int64, error) {
+	const op = "storage.sqlstore.SavePSU"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO psu ("+psuColumns+") VALUES (?, ?, ?, ?) ON CONFLICT (name) DO NOTHING RETURNING id",
+		p.Name, p.Wattage, p.Efficiency, p.Modular,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx, "SELECT id FROM psu WHERE name = ?", p.Name).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing psu: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrPSUAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetPSU(ctx context.Context, id int64) (*psu.PSU, error) {
+	return getPSU(ctx, s.q, id)
+}
+
+func getPSU(ctx context.Context, q conn, id int64) (*psu.PSU, error) {
+	const op = "storage.sqlstore.GetPSU"
+
+	res := psu.PSU{ID: id}
+	err := q.QueryRowContext(ctx,
+		"SELECT "+psuColumns+" FROM psu WHERE id = ?", id,
+	).Scan(psuFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrPSUNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+
+}
@@ -0,0 +1,796 @@
+// Package sqlstore implements storage.Repository on database/sql. The
+// sqlite and postgres packages open the database, own its migrations and
+// describe the SQL it speaks with a sqlquery.Dialect.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+	"github.com/r33ta/pc-database-manager/internal/storage/sqlquery"
+)
+
+type Storage struct {
+	db *sql.DB
+	q  conn
+}
+
+var _ storage.Repository = (*Storage)(nil)
+
+// New returns a repository on db, which speaks d.
+func New(db *sql.DB, d sqlquery.Dialect) *Storage {
+	return &Storage{db: db, q: conn{q: db, dialect: d}}
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx, so inserts can run on
+// their own or as part of a build transaction.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn runs statements on a querier in the dialect of the database.
+// Statements are written with ? placeholders and rebound before running.
+type conn struct {
+	q       querier
+	dialect sqlquery.Dialect
+}
+
+func (c conn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return c.q.ExecContext(ctx, c.dialect.Rebind(query), args...)
+}
+
+func (c conn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return c.q.QueryContext(ctx, c.dialect.Rebind(query), args...)
+}
+
+func (c conn) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return c.q.QueryRowContext(ctx, c.dialect.Rebind(query), args...)
+}
+
+// on returns a conn running statements in tx.
+func (s *Storage) on(tx *sql.Tx) conn {
+	return conn{q: tx, dialect: s.q.dialect}
+}
+
+func (s *Storage) SavePC(ctx context.Context, p pc.PC) (int64, error) {
+	const op = "storage.sqlstore.SavePC"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	id, err := savePC(ctx, s.on(tx), p)
+	if err != nil {
+		return id, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// savePC inserts the pc row and its parts. It has to run inside a
+// transaction, otherwise a failed part insert leaves a half saved pc.
+// Components are checked up front because on Postgres a foreign key violation
+// aborts the whole transaction.
+func savePC(ctx context.Context, q conn, p pc.PC) (int64, error) {
+	const op = "storage.sqlstore.SavePC"
+
+	if err := checkComponents(ctx, q, p); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO pc (name, cpu_id, motherboard_id, psu_id, case_id, cooler_id) VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (name) DO NOTHING RETURNING id",
+		append([]any{p.Name, p.CPUID}, refArgs(p)...)...,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx, "SELECT id FROM pc WHERE name = ?", p.Name).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing pc: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrPCAlreadyExists)
+	}
+	if q.dialect.IsForeignKey(err) {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrComponentNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := insertParts(ctx, q, id, p); err != nil {
+		if q.dialect.IsForeignKey(err) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrComponentNotFound)
+		}
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// ramColumns are the ram columns besides id, in ramFields order.
+const ramColumns = "name, memory_type, capacity, speed, cas_latency, modules, ecc"
+
+// ramFields returns scan destinations for ramColumns.
+func ramFields(r *ram.RAM) []any {
+	return []any{&r.Name, &r.MemoryType, &r.Capacity, &r.Speed, &r.CASLatency, &r.Modules, &r.ECC}
+}
+
+func (s *Storage) SaveRAM(ctx context.Context, r ram.RAM) (int64, error) {
+	return saveRAM(ctx, s.q, r)
+}
+
+func saveRAM(ctx context.Context, q conn, r ram.RAM) (int64, error) {
+	const op = "storage.sqlstore.SaveRAM"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO ram ("+ramColumns+") VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT (name, memory_type, capacity) DO NOTHING RETURNING id",
+		r.Name, r.MemoryType, r.Capacity, r.Speed, r.CASLatency, r.Modules, r.ECC,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx,
+			"SELECT id FROM ram WHERE name = ? AND memory_type = ? AND capacity = ?",
+			r.Name, r.MemoryType, r.Capacity,
+		).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing ram: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrRAMAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// cpuColumns are the cpu columns besides id, in cpuFields order.
+const cpuColumns = "name, vendor, socket, cores, threads, architecture, base_clock, boost_clock, l3_cache, tdp, integrated_graphics"
+
+// cpuFields returns scan destinations for cpuColumns.
+func cpuFields(c *cpu.CPU) []any {
+	return []any{
+		&c.Name, &c.Vendor, &c.Socket, &c.Cores, &c.Threads, &c.Architecture,
+		&c.BaseClock, &c.BoostClock, &c.L3Cache, &c.TDP, &c.IntegratedGraphics,
+	}
+}
+
+func (s *Storage) SaveCPU(ctx context.Context, c cpu.CPU) (int64, error) {
+	return saveCPU(ctx, s.q, c)
+}
+
+func saveCPU(ctx context.Context, q conn, c cpu.CPU) (int64, error) {
+	const op = "storage.sqlstore.SaveCPU"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO cpu ("+cpuColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (name, base_clock) DO NOTHING RETURNING id",
+		c.Name, c.Vendor, c.Socket, c.Cores, c.Threads, c.Architecture, c.BaseClock, c.BoostClock, c.L3Cache, c.TDP, c.IntegratedGraphics,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx,
+			"SELECT id FROM cpu WHERE name = ? AND base_clock = ?",
+			c.Name, c.BaseClock,
+		).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing cpu: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrCPUAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// gpuColumns are the gpu columns besides id, in gpuFields order.
+const gpuColumns = "name, chip_vendor, manufacturer, memory, memory_type, bus_width, frequency, board_power, pcie_gen, pcie_lanes, length, hdmi_outputs, displayport_outputs, dvi_outputs, usbc_outputs"
+
+// gpuFields returns scan destinations for gpuColumns.
+func gpuFields(g *gpu.GPU) []any {
+	return []any{
+		&g.Name, &g.ChipVendor, &g.Manufacturer, &g.Memory, &g.MemoryType, &g.BusWidth, &g.Frequency,
+		&g.BoardPower, &g.PCIeGen, &g.PCIeLanes, &g.Length,
+		&g.Outputs.HDMI, &g.Outputs.DisplayPort, &g.Outputs.DVI, &g.Outputs.USBC,
+	}
+}
+
+func (s *Storage) SaveGPU(ctx context.Context, g gpu.GPU) (int64, error) {
+	return saveGPU(ctx, s.q, g)
+}
+
+func saveGPU(ctx context.Context, q conn, g gpu.GPU) (int64, error) {
+	const op = "storage.sqlstore.SaveGPU"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO gpu ("+gpuColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (manufacturer, name, memory) DO NOTHING RETURNING id",
+		g.Name, g.ChipVendor, g.Manufacturer, g.Memory, g.MemoryType, g.BusWidth, g.Frequency, g.BoardPower, g.PCIeGen, g.PCIeLanes, g.Length, g.Outputs.HDMI, g.Outputs.DisplayPort, g.Outputs.DVI, g.Outputs.USBC,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx,
+			"SELECT id FROM gpu WHERE manufacturer = ? AND name = ? AND memory = ?",
+			g.Manufacturer, g.Name, g.Memory,
+		).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing gpu: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrGPUAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// memoryColumns are the memory columns besides id, in memoryFields order.
+const memoryColumns = "name, capacity, type, interface, form_factor, read_speed, write_speed, tbw"
+
+// memoryFields returns scan destinations for memoryColumns.
+func memoryFields(m *memory.Memory) []any {
+	return []any{&m.Name, &m.Capacity, &m.StorageType, &m.Interface, &m.FormFactor, &m.ReadSpeed, &m.WriteSpeed, &m.TBW}
+}
+
+func (s *Storage) SaveMemory(ctx context.Context, m memory.Memory) (int64, error) {
+	return saveMemory(ctx, s.q, m)
+}
+
+func saveMemory(ctx context.Context, q conn, m memory.Memory) (int64, error) {
+	const op = "storage.sqlstore.SaveMemory"
+
+	var id int64
+	err := q.QueryRowContext(ctx,
+		"INSERT INTO memory ("+memoryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (name, capacity, type) DO NOTHING RETURNING id",
+		m.Name, m.Capacity, m.StorageType, m.Interface, m.FormFactor, m.ReadSpeed, m.WriteSpeed, m.TBW,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		var existingID int64
+		if err := q.QueryRowContext(ctx,
+			"SELECT id FROM memory WHERE name = ? AND capacity = ? AND type = ?",
+			m.Name, m.Capacity, m.StorageType,
+		).Scan(&existingID); err != nil {
+			return 0, fmt.Errorf("%s: find existing memory: %w", op, err)
+		}
+		return existingID, fmt.Errorf("%s: %w", op, storage.ErrMemoryAlreadyExists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Storage) GetPC(ctx context.Context, id int64) (*pc.PC, error) {
+	const op = "storage.sqlstore.GetPC"
+
+	res := []pc.PC{{ID: id}}
+	refs, setRefs := scanRefs(&res[0])
+	err := s.q.QueryRowContext(ctx,
+		"SELECT name, cpu_id, motherboard_id, psu_id, case_id, cooler_id FROM pc WHERE id = ?", id,
+	).Scan(append([]any{&res[0].Name, &res[0].CPUID}, refs...)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrPCNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	setRefs()
+
+	if err := loadParts(ctx, s.q, res); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &res[0], nil
+}
+
+// expandedQuery selects the pc with its components, one row per part. The
+// optional components and the component of each part are LEFT JOINed, their
+// columns are NULL where there is none. It takes the pc id once per
+// junction table and once for the pc.
+var expandedQuery = `
+	SELECT pc.name,
+		cpu.id, ` + qualify("cpu", cpuColumns) + `,
+		motherboard.id, ` + qualify("motherboard", motherboardColumns) + `,
+		psu.id, ` + qualify("psu", psuColumns) + `,
+		pccase.id, ` + qualify("pccase", caseColumns) + `,
+		cooler.id, ` + qualify("cooler", coolerColumns) + `,
+		part.kind, part.quantity, part.slot,
+		ram.id, ` + qualify("ram", ramColumns) + `,
+		gpu.id, ` + qualify("gpu", gpuColumns) + `,
+		memory.id, ` + qualify("memory", memoryColumns) + `,
+		peripheral.id, ` + qualify("peripheral", peripheralColumns) + `
+	FROM pc
+	JOIN cpu ON cpu.id = pc.cpu_id
+	LEFT JOIN motherboard ON motherboard.id = pc.motherboard_id
+	LEFT JOIN psu ON psu.id = pc.psu_id
+	LEFT JOIN pccase ON pccase.id = pc.case_id
+	LEFT JOIN cooler ON cooler.id = pc.cooler_id
+	LEFT JOIN (
+		SELECT 'ram' AS kind, id, pc_id, ram_id AS component_id, quantity, slot FROM pc_ram WHERE pc_id = ?
+		UNION ALL
+		SELECT 'gpu', id, pc_id, gpu_id, quantity, slot FROM pc_gpu WHERE pc_id = ?
+		UNION ALL
+		SELECT 'memory', id, pc_id, memory_id, quantity, slot FROM pc_memory WHERE pc_id = ?
+		UNION ALL
+		SELECT 'peripheral', id, pc_id, peripheral_id, quantity, slot FROM pc_peripheral WHERE pc_id = ?
+	) part ON part.pc_id = pc.id
+	LEFT JOIN ram ON part.kind = 'ram' AND ram.id = part.component_id
+	LEFT JOIN gpu ON part.kind = 'gpu' AND gpu.id = part.component_id
+	LEFT JOIN memory ON part.kind = 'memory' AND memory.id = part.component_id
+	LEFT JOIN peripheral ON part.kind = 'peripheral' AND peripheral.id = part.component_id
+	WHERE pc.id = ?
+	ORDER BY part.id
+`
+
+// expandedRow is a row of expandedQuery. Components left out of the row keep
+// a zero id.
+type expandedRow struct {
+	name        string
+	cpu         cpu.CPU
+	motherboard motherboard.Motherboard
+	psu         psu.PSU
+	pccase      pccase.Case
+	cooler      cooler.Cooler
+	kind        sql.NullString
+	quantity    int64
+	slot        string
+	ram         ram.RAM
+	gpu         gpu.GPU
+	memory      memory.Memory
+	peripheral  peripheral.Peripheral
+}
+
+// dest returns the scan destinations of the columns of expandedQuery.
+func (r *expandedRow) dest() []any {
+	dest := append([]any{&r.name, &r.cpu.ID}, cpuFields(&r.cpu)...)
+	dest = append(dest, orNull(&r.motherboard.ID, motherboardFields(&r.motherboard)...)...)
+	dest = append(dest, orNull(&r.psu.ID, psuFields(&r.psu)...)...)
+	dest = append(dest, orNull(&r.pccase.ID, caseFields(&r.pccase)...)...)
+	dest = append(dest, orNull(&r.cooler.ID, coolerFields(&r.cooler)...)...)
+	dest = append(dest, &r.kind)
+	dest = append(dest, orNull(&r.quantity, &r.slot)...)
+	dest = append(dest, orNull(&r.ram.ID, ramFields(&r.ram)...)...)
+	dest = append(dest, orNull(&r.gpu.ID, gpuFields(&r.gpu)...)...)
+	dest = append(dest, orNull(&r.memory.ID, memoryFields(&r.memory)...)...)
+	dest = append(dest, orNull(&r.peripheral.ID, peripheralFields(&r.peripheral)...)...)
+
+	return dest
+}
+
+// GetExpandedPC reads the pc and its components with a single statement, so
+// a component changed meanwhile cannot leave the pc half resolved.
+func (s *Storage) GetExpandedPC(ctx context.Context, id int64) (*pc.Expanded, error) {
+	const op = "storage.sqlstore.GetExpandedPC"
+
+	rows, err := s.q.QueryContext(ctx, expandedQuery, id, id, id, id, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+	defer rows.Close()
+
+	var res *pc.Expanded
+	for rows.Next() {
+		var r expandedRow
+		if err := rows.Scan(r.dest()...); err != nil {
+			return nil, fmt.Errorf("%s: scan row: %w", op, err)
+		}
+
+		if res == nil {
+			res = &pc.Expanded{ID: id, Name: r.name, CPU: r.cpu}
+			if r.motherboard.ID != 0 {
+				res.Motherboard = &r.motherboard
+			}
+			if r.psu.ID != 0 {
+				res.PSU = &r.psu
+			}
+			if r.pccase.ID != 0 {
+				res.Case = &r.pccase
+			}
+			if r.cooler.ID != 0 {
+				res.Cooler = &r.cooler
+			}
+		}
+
+		switch r.kind.String {
+		case ramParts.component:
+			res.RAM = append(res.RAM, pc.RAMPart{RAM: r.ram, Quantity: r.quantity, Slot: r.slot})
+		case gpuParts.component:
+			res.GPU = append(res.GPU, pc.GPUPart{GPU: r.gpu, Quantity: r.quantity, Slot: r.slot})
+		case memoryParts.component:
+			res.Memory = append(res.Memory, pc.MemoryPart{Memory: r.memory, Quantity: r.quantity, Slot: r.slot})
+		case peripheralParts.component:
+			res.Peripherals = append(res.Peripherals, pc.PeripheralPart{Peripheral: r.peripheral.WithOwnDetails(), Quantity: r.quantity, Slot: r.slot})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if res == nil {
+		return nil, storage.ErrPCNotFound
+	}
+
+	return res, nil
+}
+
+func (s *Storage) GetRAM(ctx context.Context, id int64) (*ram.RAM, error) {
+	const op = "storage.sqlstore.GetRAM"
+
+	res := ram.RAM{ID: id}
+	err := s.q.QueryRowContext(ctx,
+		"SELECT "+ramColumns+" FROM ram WHERE id = ?", id,
+	).Scan(ramFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrRAMNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+}
+
+func (s *Storage) GetCPU(ctx context.Context, id int64) (*cpu.CPU, error) {
+	return getCPU(ctx, s.q, id)
+}
+
+func getCPU(ctx context.Context, q conn, id int64) (*cpu.CPU, error) {
+	const op = "storage.sqlstore.GetCPU"
+
+	res := cpu.CPU{ID: id}
+	err := q.QueryRowContext(ctx,
+		"SELECT "+cpuColumns+" FROM cpu WHERE id = ?", id,
+	).Scan(cpuFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrCPUNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+
+}
+
+func (s *Storage) GetGPU(ctx context.Context, id int64) (*gpu.GPU, error) {
+	const op = "storage.sqlstore.GetGPU"
+
+	res := gpu.GPU{ID: id}
+	err := s.q.QueryRowContext(ctx,
+		"SELECT "+gpuColumns+" FROM gpu WHERE id = ?", id,
+	).Scan(gpuFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrGPUNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+}
+
+func (s *Storage) GetMemory(ctx context.Context, id int64) (*memory.Memory, error) {
+	const op = "storage.sqlstore.GetMemory"
+
+	res := memory.Memory{ID: id}
+	err := s.q.QueryRowContext(ctx,
+		"SELECT "+memoryColumns+" FROM memory WHERE id = ?", id,
+	).Scan(memoryFields(&res)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrMemoryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return &res, nil
+}
+
+// UpdatePC replaces the pc row and all of its parts.
+func (s *Storage) UpdatePC(ctx context.Context, p pc.PC) error {
+	const op = "storage.sqlstore.UpdatePC"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: begin transaction: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := checkComponents(ctx, s.on(tx), p); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := s.on(tx).ExecContext(ctx,
+		"UPDATE pc SET name = ?, cpu_id = ?, motherboard_id = ?, psu_id = ?, case_id = ?, cooler_id = ? WHERE id = ?",
+		append(append([]any{p.Name, p.CPUID}, refArgs(p)...), p.ID)...,
+	)
+	if s.q.dialect.IsUnique(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrPCAlreadyExists)
+	}
+	if s.q.dialect.IsForeignKey(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrComponentNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	if err := checkAffected(op, res, storage.ErrPCNotFound); err != nil {
+		return err
+	}
+
+	if err := deleteParts(ctx, s.on(tx), p.ID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := insertParts(ctx, s.on(tx), p.ID, p); err != nil {
+		if s.q.dialect.IsForeignKey(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrComponentNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: commit: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) UpdateRAM(ctx context.Context, r ram.RAM) error {
+	const op = "storage.sqlstore.UpdateRAM"
+
+	res, err := s.q.ExecContext(ctx,
+		"UPDATE ram SET name = ?, memory_type = ?, capacity = ?, speed = ?, cas_latency = ?, modules = ?, ecc = ? WHERE id = ?",
+		r.Name, r.MemoryType, r.Capacity, r.Speed, r.CASLatency, r.Modules, r.ECC, r.ID,
+	)
+	if s.q.dialect.IsUnique(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrRAMAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrRAMNotFound)
+}
+
+func (s *Storage) UpdateCPU(ctx context.Context, c cpu.CPU) error {
+	const op = "storage.sqlstore.UpdateCPU"
+
+	res, err := s.q.ExecContext(ctx,
+		`UPDATE cpu SET name = ?, vendor = ?, socket = ?, cores = ?, threads = ?, architecture = ?,
+			base_clock = ?, boost_clock = ?, l3_cache = ?, tdp = ?, integrated_graphics = ?
+		WHERE id = ?`,
+		c.Name, c.Vendor, c.Socket, c.Cores, c.Threads, c.Architecture, c.BaseClock, c.BoostClock, c.L3Cache, c.TDP, c.IntegratedGraphics, c.ID,
+	)
+	if s.q.dialect.IsUnique(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrCPUAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrCPUNotFound)
+}
+
+func (s *Storage) UpdateGPU(ctx context.Context, g gpu.GPU) error {
+	const op = "storage.sqlstore.UpdateGPU"
+
+	res, err := s.q.ExecContext(ctx,
+		`UPDATE gpu SET name = ?, chip_vendor = ?, manufacturer = ?, memory = ?, memory_type = ?, bus_width = ?, frequency = ?,
+			board_power = ?, pcie_gen = ?, pcie_lanes = ?, length = ?,
+			hdmi_outputs = ?, displayport_outputs = ?, dvi_outputs = ?, usbc_outputs = ?
+		WHERE id = ?`,
+		g.Name, g.ChipVendor, g.Manufacturer, g.Memory, g.MemoryType, g.BusWidth, g.Frequency, g.BoardPower, g.PCIeGen, g.PCIeLanes, g.Length, g.Outputs.HDMI, g.Outputs.DisplayPort, g.Outputs.DVI, g.Outputs.USBC, g.ID,
+	)
+	if s.q.dialect.IsUnique(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrGPUAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrGPUNotFound)
+}
+
+func (s *Storage) UpdateMemory(ctx context.Context, m memory.Memory) error {
+	const op = "storage.sqlstore.UpdateMemory"
+
+	res, err := s.q.ExecContext(ctx,
+		`UPDATE memory SET name = ?, capacity = ?, type = ?, interface = ?, form_factor = ?,
+			read_speed = ?, write_speed = ?, tbw = ?
+		WHERE id = ?`,
+		m.Name, m.Capacity, m.StorageType, m.Interface, m.FormFactor, m.ReadSpeed, m.WriteSpeed, m.TBW, m.ID,
+	)
+	if s.q.dialect.IsUnique(err) {
+		return fmt.Errorf("%s: %w", op, storage.ErrMemoryAlreadyExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrMemoryNotFound)
+}
+
+func (s *Storage) DeletePC(ctx context.Context, id int64) error {
+	const op = "storage.sqlstore.DeletePC"
+
+	res, err := s.q.ExecContext(ctx, "DELETE FROM pc WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrPCNotFound)
+}
+
+func (s *Storage) DeleteRAM(ctx context.Context, id int64) error {
+	const op = "storage.sqlstore.DeleteRAM"
+
+	res, err := s.q.ExecContext(ctx, "DELETE FROM ram WHERE id = ?", id)
+	if s.q.dialect.IsForeignKey(err) {
+		return storage.ErrRAMInUse
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrRAMNotFound)
+}
+
+func (s *Storage) DeleteCPU(ctx context.Context, id int64) error {
+	const op = "storage.sqlstore.DeleteCPU"
+
+	res, err := s.q.ExecContext(ctx, "DELETE FROM cpu WHERE id = ?", id)
+	if s.q.dialect.IsForeignKey(err) {
+		return storage.ErrCPUInUse
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrCPUNotFound)
+}
+
+func (s *Storage) DeleteGPU(ctx context.Context, id int64) error {
+	const op = "storage.sqlstore.DeleteGPU"
+
+	res, err := s.q.ExecContext(ctx, "DELETE FROM gpu WHERE id = ?", id)
+	if s.q.dialect.IsForeignKey(err) {
+		return storage.ErrGPUInUse
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrGPUNotFound)
+}
+
+func (s *Storage) DeleteMemory(ctx context.Context, id int64) error {
+	const op = "storage.sqlstore.DeleteMemory"
+
+	res, err := s.q.ExecContext(ctx, "DELETE FROM memory WHERE id = ?", id)
+	if s.q.dialect.IsForeignKey(err) {
+		return storage.ErrMemoryInUse
+	}
+	if err != nil {
+		return fmt.Errorf("%s: execute statement: %w", op, err)
+	}
+
+	return checkAffected(op, res, storage.ErrMemoryNotFound)
+}
+
+// checkAffected returns errNotFound if the statement did not touch any row.
+func checkAffected(op string, res sql.Result, errNotFound error) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+// exists reports whether table has a row with the id. The table name always
+// comes from code.
+func exists(ctx context.Context, q conn, table string, id int64) (bool, error) {
+	var ok bool
+	err := q.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM "+table+" WHERE id = ?)", id).Scan(&ok)
+	return ok, err
+}
+
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+func (s *Storage) Ping() error {
+	return s.db.Ping()
+}
+
+// qualify prefixes each of the comma separated columns with table.
+func qualify(table, columns string) string {
+	cols := strings.Split(columns, ", ")
+	for i, c := range cols {
+		cols[i] = table + "." + c
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+// orNull returns scan destinations for the columns of a LEFT JOINed row,
+// the first of them its id. Where there is no row they are left as they are.
+func orNull(id any, fields ...any) []any {
+	dest := make([]any, 0, len(fields)+1)
+	for _, f := range append([]any{id}, fields...) {
+		dest = append(dest, nullable{f})
+	}
+
+	return dest
+}
+
+// nullable scans into dest unless the column is NULL. The value is converted
+// as database/sql converts it for the sql.Null type of the kind of dest.
+type nullable struct {
+	dest any
+}
+
+func (n nullable) Scan(src any) error {
+	if src == nil {
+		return nil
+	}
+
+	v := reflect.ValueOf(n.dest).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		var s sql.NullString
+		if err := s.Scan(src); err != nil {
+			return err
+		}
+		v.SetString(s.String)
+	case reflect.Int64:
+		var i sql.NullInt64
+		if err := i.Scan(src); err != nil {
+			return err
+		}
+		v.SetInt(i.Int64)
+	case reflect.Float64:
+		var f sql.NullFloat64
+		if err := f.Scan(src); err != nil {
+			return err
+		}
+		v.SetFloat(f.Float64)
+	case reflect.Bool:
+		var b sql.NullBool
+		if err := b.Scan(src); err != nil {
+			return err
+		}
+		v.SetBool(b.Bool)
+	default:
+		return fmt.Errorf("cannot scan into %T", n.dest)
+	}
+
+	return nil
+}
@@ -1,16 +1,50 @@
 package storage
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrPCNotFound          = errors.New("pc not found")
-	ErrPCAlreadyExists     = errors.New("pc already exists")
-	ErrRAMNotFound         = errors.New("ram not found")
-	ErrRAMAlreadyExists    = errors.New("ram already exists")
-	ErrCPUAlreadyExists    = errors.New("cpu already exists")
-	ErrCPUNotFound         = errors.New("cpu not found")
-	ErrGPUAlreadyExists    = errors.New("gpu already exists")
-	ErrGPUNotFound         = errors.New("gpu not found")
-	ErrMemoryAlreadyExists = errors.New("memory already exists")
-	ErrMemoryNotFound      = errors.New("memory not found")
+	ErrPCNotFound               = errors.New("pc not found")
+	ErrPCAlreadyExists          = errors.New("pc already exists")
+	ErrRAMNotFound              = errors.New("ram not found")
+	ErrRAMAlreadyExists         = errors.New("ram already exists")
+	ErrRAMInUse                 = errors.New("ram is used by a pc")
+	ErrCPUAlreadyExists         = errors.New("cpu already exists")
+	ErrCPUNotFound              = errors.New("cpu not found")
+	ErrCPUInUse                 = errors.New("cpu is used by a pc")
+	ErrGPUAlreadyExists         = errors.New("gpu already exists")
+	ErrGPUNotFound              = errors.New("gpu not found")
+	ErrGPUInUse                 = errors.New("gpu is used by a pc")
+	ErrMemoryAlreadyExists      = errors.New("memory already exists")
+	ErrMemoryNotFound           = errors.New("memory not found")
+	ErrMemoryInUse              = errors.New("memory is used by a pc")
+	ErrMotherboardNotFound      = errors.New("motherboard not found")
+	ErrMotherboardAlreadyExists = errors.New("motherboard already exists")
+	ErrPSUNotFound              = errors.New("psu not found")
+	ErrPSUAlreadyExists         = errors.New("psu already exists")
+	ErrCaseNotFound             = errors.New("case not found")
+	ErrCaseAlreadyExists        = errors.New("case already exists")
+	ErrCoolerNotFound           = errors.New("cooler not found")
+	ErrCoolerAlreadyExists      = errors.New("cooler already exists")
+	ErrPeripheralNotFound       = errors.New("peripheral not found")
+	ErrPeripheralAlreadyExists  = errors.New("peripheral already exists")
+	ErrPeripheralInUse          = errors.New("peripheral is used by a pc")
+	ErrComponentNotFound        = errors.New("component not found")
 )
+
+// ComponentNotFoundError reports which component referenced by a pc does not
+// exist. It matches ErrComponentNotFound with errors.Is.
+type ComponentNotFoundError struct {
+	Component string
+	ID        int64
+}
+
+func (e *ComponentNotFoundError) Error() string {
+	return fmt.Sprintf("%s with id %d not found", e.Component, e.ID)
+}
+
+func (e *ComponentNotFoundError) Unwrap() error {
+	return ErrComponentNotFound
+}
@@ -0,0 +1,718 @@
+// Package storagetest checks that a storage.Repository behaves like every
+// other backend: the same results and the same errors, which handlers turn
+// into 404 and 409 responses.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/r33ta/pc-database-manager/internal/lib/units"
+	"github.com/r33ta/pc-database-manager/internal/models/cooler"
+	"github.com/r33ta/pc-database-manager/internal/models/cpu"
+	"github.com/r33ta/pc-database-manager/internal/models/gpu"
+	"github.com/r33ta/pc-database-manager/internal/models/memory"
+	"github.com/r33ta/pc-database-manager/internal/models/motherboard"
+	"github.com/r33ta/pc-database-manager/internal/models/pc"
+	"github.com/r33ta/pc-database-manager/internal/models/pccase"
+	"github.com/r33ta/pc-database-manager/internal/models/peripheral"
+	"github.com/r33ta/pc-database-manager/internal/models/psu"
+	"github.com/r33ta/pc-database-manager/internal/models/ram"
+	"github.com/r33ta/pc-database-manager/internal/storage"
+)
+
+// Run runs the contract against repositories made by newRepo, which has to
+// return an empty one on every call.
+func Run(t *testing.T, newRepo func(t *testing.T) storage.Repository) {
+	t.Run("RAM", func(t *testing.T) { testComponent(t, newRepo(t), ramComponent) })
+	t.Run("CPU", func(t *testing.T) { testComponent(t, newRepo(t), cpuComponent) })
+	t.Run("GPU", func(t *testing.T) { testComponent(t, newRepo(t), gpuComponent) })
+	t.Run("Memory", func(t *testing.T) { testComponent(t, newRepo(t), memoryComponent) })
+	t.Run("Peripheral", func(t *testing.T) { testComponent(t, newRepo(t), peripheralComponent) })
+	t.Run("Motherboard", func(t *testing.T) { testComponent(t, newRepo(t), motherboardComponent) })
+	t.Run("PSU", func(t *testing.T) { testComponent(t, newRepo(t), psuComponent) })
+	t.Run("Case", func(t *testing.T) { testComponent(t, newRepo(t), caseComponent) })
+	t.Run("Cooler", func(t *testing.T) { testComponent(t, newRepo(t), coolerComponent) })
+	t.Run("PC", func(t *testing.T) { testPC(t, newRepo(t)) })
+	t.Run("ComponentInUse", func(t *testing.T) { testInUse(t, newRepo(t)) })
+	t.Run("List", func(t *testing.T) { testList(t, newRepo(t)) })
+	t.Run("Build", func(t *testing.T) { testBuild(t, newRepo(t)) })
+}
+
+// component describes the repository methods of one component type. Update
+// and Delete are nil for components that cannot be changed once saved.
+type component[T any] struct {
+	// a and b are valid components with different natural keys.
+	a, b     T
+	withID   func(v T, id int64) T
+	save     func(ctx context.Context, r storage.Repository, v T) (int64, error)
+	get      func(ctx context.Context, r storage.Repository, id int64) (*T, error)
+	update   func(ctx context.Context, r storage.Repository, v T) error
+	delete   func(ctx context.Context, r storage.Repository, id int64) error
+	notFound error
+	exists   error
+}
+
+func testComponent[T any](t *testing.T, r storage.Repository, c component[T]) {
+	ctx := context.Background()
+
+	idA, err := c.save(ctx, r, c.a)
+	if err != nil {
+		t.Fatalf("save a: %v", err)
+	}
+	idB, err := c.save(ctx, r, c.b)
+	if err != nil {
+		t.Fatalf("save b: %v", err)
+	}
+	if idA == idB {
+		t.Fatalf("save returned id %d twice", idA)
+	}
+
+	got, err := c.get(ctx, r, idA)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if want := c.withID(c.a, idA); !reflect.DeepEqual(*got, want) {
+		t.Errorf("get = %+v, want %+v", *got, want)
+	}
+
+	if _, err := c.save(ctx, r, c.a); !errors.Is(err, c.exists) {
+		t.Errorf("save duplicate: error = %v, want %v", err, c.exists)
+	}
+	if _, err := c.get(ctx, r, idB+100); !errors.Is(err, c.notFound) {
+		t.Errorf("get missing: error = %v, want %v", err, c.notFound)
+	}
+
+	if c.update == nil {
+		return
+	}
+
+	if err := c.update(ctx, r, c.withID(c.b, idA)); !errors.Is(err, c.exists) {
+		t.Errorf("update onto b: error = %v, want %v", err, c.exists)
+	}
+	if err := c.update(ctx, r, c.withID(c.a, idB+100)); !errors.Is(err, c.notFound) {
+		t.Errorf("update missing: error = %v, want %v", err, c.notFound)
+	}
+
+	if err := c.delete(ctx, r, idB); err != nil {
+		t.Fatalf("delete b: %v", err)
+	}
+	if _, err := c.get(ctx, r, idB); !errors.Is(err, c.notFound) {
+		t.Errorf("get deleted: error = %v, want %v", err, c.notFound)
+	}
+	if err := c.delete(ctx, r, idB); !errors.Is(err, c.notFound) {
+		t.Errorf("delete deleted: error = %v, want %v", err, c.notFound)
+	}
+
+	// b's natural key is free again once it is deleted
+	if err := c.update(ctx, r, c.withID(c.b, idA)); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	got, err = c.get(ctx, r, idA)
+	if err != nil {
+		t.Fatalf("get updated: %v", err)
+	}
+	if want := c.withID(c.b, idA); !reflect.DeepEqual(*got, want) {
+		t.Errorf("get updated = %+v, want %+v", *got, want)
+	}
+}
+
+var ramComponent = component[ram.RAM]{
+	a: ram.RAM{Name: "Vengeance", MemoryType: ram.DDR5, Capacity: 32 * units.GiB, Speed: 6000, CASLatency: 30, Modules: 2},
+	b: ram.RAM{Name: "Trident Z", MemoryType: ram.DDR4ECC, Capacity: 16 * units.GiB, Speed: 3200, CASLatency: 16, Modules: 1, ECC: true},
+	withID: func(v ram.RAM, id int64) ram.RAM {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v ram.RAM) (int64, error) {
+		return r.SaveRAM(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*ram.RAM, error) {
+		return r.GetRAM(ctx, id)
+	},
+	update: func(ctx context.Context, r storage.Repository, v ram.RAM) error {
+		return r.UpdateRAM(ctx, v)
+	},
+	delete: func(ctx context.Context, r storage.Repository, id int64) error {
+		return r.DeleteRAM(ctx, id)
+	},
+	notFound: storage.ErrRAMNotFound,
+	exists:   storage.ErrRAMAlreadyExists,
+}
+
+var cpuComponent = component[cpu.CPU]{
+	a: cpu.CPU{
+		Name: "Ryzen 7 7800X3D", Vendor: cpu.AMD, Socket: "AM5", Cores: 8, Threads: 16,
+		Architecture: "Zen 4", BaseClock: 4200 * units.MHz, BoostClock: 5 * units.GHz,
+		L3Cache: 96 * units.MiB, TDP: 120, IntegratedGraphics: true,
+	},
+	b: cpu.CPU{
+		Name: "Core i5-12400F", Vendor: cpu.Intel, Socket: "LGA1700", Cores: 6, Threads: 12,
+		Architecture: "Alder Lake", BaseClock: 2500 * units.MHz, BoostClock: 4400 * units.MHz,
+		L3Cache: 18 * units.MiB, TDP: 65,
+	},
+	withID: func(v cpu.CPU, id int64) cpu.CPU {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v cpu.CPU) (int64, error) {
+		return r.SaveCPU(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*cpu.CPU, error) {
+		return r.GetCPU(ctx, id)
+	},
+	update: func(ctx context.Context, r storage.Repository, v cpu.CPU) error {
+		return r.UpdateCPU(ctx, v)
+	},
+	delete: func(ctx context.Context, r storage.Repository, id int64) error {
+		return r.DeleteCPU(ctx, id)
+	},
+	notFound: storage.ErrCPUNotFound,
+	exists:   storage.ErrCPUAlreadyExists,
+}
+
+var gpuComponent = component[gpu.GPU]{
+	a: gpu.GPU{
+		Name: "RTX 4070", ChipVendor: gpu.NVIDIA, Manufacturer: "ASUS", Memory: 12 * units.GiB,
+		MemoryType: gpu.GDDR6X, BusWidth: 192, Frequency: 2475 * units.MHz, BoardPower: 200,
+		PCIeGen: 4, PCIeLanes: 16, Length: 267, Outputs: gpu.Outputs{HDMI: 1, DisplayPort: 3},
+	},
+	b: gpu.GPU{
+		Name: "RX 7800 XT", ChipVendor: gpu.AMD, Manufacturer: "Sapphire", Memory: 16 * units.GiB,
+		MemoryType: gpu.GDDR6, BusWidth: 256, Frequency: 2430 * units.MHz, BoardPower: 263,
+		PCIeGen: 4, PCIeLanes: 16, Length: 280, Outputs: gpu.Outputs{HDMI: 2, DisplayPort: 2, USBC: 1},
+	},
+	withID: func(v gpu.GPU, id int64) gpu.GPU {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v gpu.GPU) (int64, error) {
+		return r.SaveGPU(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*gpu.GPU, error) {
+		return r.GetGPU(ctx, id)
+	},
+	update: func(ctx context.Context, r storage.Repository, v gpu.GPU) error {
+		return r.UpdateGPU(ctx, v)
+	},
+	delete: func(ctx context.Context, r storage.Repository, id int64) error {
+		return r.DeleteGPU(ctx, id)
+	},
+	notFound: storage.ErrGPUNotFound,
+	exists:   storage.ErrGPUAlreadyExists,
+}
+
+var memoryComponent = component[memory.Memory]{
+	a: memory.Memory{
+		Name: "990 Pro", Capacity: 2 * units.TB, StorageType: memory.NVMe, Interface: memory.PCIe4,
+		FormFactor: memory.M2Size2280, ReadSpeed: 7450, WriteSpeed: 6900, TBW: 1200,
+	},
+	b: memory.Memory{
+		Name: "Barracuda", Capacity: 4 * units.TB, StorageType: memory.HDD, Interface: memory.SATA,
+		FormFactor: memory.Inch35, ReadSpeed: 190, WriteSpeed: 190,
+	},
+	withID: func(v memory.Memory, id int64) memory.Memory {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v memory.Memory) (int64, error) {
+		return r.SaveMemory(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*memory.Memory, error) {
+		return r.GetMemory(ctx, id)
+	},
+	update: func(ctx context.Context, r storage.Repository, v memory.Memory) error {
+		return r.UpdateMemory(ctx, v)
+	},
+	delete: func(ctx context.Context, r storage.Repository, id int64) error {
+		return r.DeleteMemory(ctx, id)
+	},
+	notFound: storage.ErrMemoryNotFound,
+	exists:   storage.ErrMemoryAlreadyExists,
+}
+
+var peripheralComponent = component[peripheral.Peripheral]{
+	a: peripheral.Peripheral{
+		Name: "27GP850", Type: peripheral.Monitor, Manufacturer: "LG", Connection: peripheral.DisplayPort,
+		Monitor: &peripheral.MonitorDetails{Diagonal: 27, Width: 2560, Height: 1440, RefreshRate: 165, Panel: "IPS"},
+	},
+	b: peripheral.Peripheral{
+		Name: "K70", Type: peripheral.Keyboard, Manufacturer: "Corsair", Connection: peripheral.USB,
+		Keyboard: &peripheral.KeyboardDetails{Layout: "ANSI", Switches: "Cherry MX Red"},
+	},
+	withID: func(v peripheral.Peripheral, id int64) peripheral.Peripheral {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v peripheral.Peripheral) (int64, error) {
+		return r.SavePeripheral(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*peripheral.Peripheral, error) {
+		return r.GetPeripheral(ctx, id)
+	},
+	update: func(ctx context.Context, r storage.Repository, v peripheral.Peripheral) error {
+		return r.UpdatePeripheral(ctx, v)
+	},
+	delete: func(ctx context.Context, r storage.Repository, id int64) error {
+		return r.DeletePeripheral(ctx, id)
+	},
+	notFound: storage.ErrPeripheralNotFound,
+	exists:   storage.ErrPeripheralAlreadyExists,
+}
+
+var motherboardComponent = component[motherboard.Motherboard]{
+	a: motherboard.Motherboard{
+		Name: "B650 Tomahawk", Socket: "AM5", Chipset: "B650", FormFactor: motherboard.ATX,
+		MemoryType: ram.DDR5, RAMSlots: 4, MaxMemory: 192 * units.GiB, M2Slots: 3, SATAPorts: 6,
+	},
+	b: motherboard.Motherboard{
+		Name: "B760M Pro", Socket: "LGA1700", Chipset: "B760", FormFactor: motherboard.MicroATX,
+		MemoryType: ram.DDR4, RAMSlots: 2, MaxMemory: 64 * units.GiB, M2Slots: 2, SATAPorts: 4,
+	},
+	withID: func(v motherboard.Motherboard, id int64) motherboard.Motherboard {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v motherboard.Motherboard) (int64, error) {
+		return r.SaveMotherboard(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*motherboard.Motherboard, error) {
+		return r.GetMotherboard(ctx, id)
+	},
+	notFound: storage.ErrMotherboardNotFound,
+	exists:   storage.ErrMotherboardAlreadyExists,
+}
+
+var psuComponent = component[psu.PSU]{
+	a: psu.PSU{Name: "RM850x", Wattage: 850, Efficiency: psu.Gold, Modular: true},
+	b: psu.PSU{Name: "CX550", Wattage: 550, Efficiency: psu.Bronze},
+	withID: func(v psu.PSU, id int64) psu.PSU {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v psu.PSU) (int64, error) {
+		return r.SavePSU(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*psu.PSU, error) {
+		return r.GetPSU(ctx, id)
+	},
+	notFound: storage.ErrPSUNotFound,
+	exists:   storage.ErrPSUAlreadyExists,
+}
+
+var caseComponent = component[pccase.Case]{
+	a: pccase.Case{Name: "Meshify 2", FormFactor: motherboard.EATX, MaxGPULength: 467, MaxCoolerHeight: 185},
+	b: pccase.Case{Name: "NR200", FormFactor: motherboard.MiniITX, MaxGPULength: 330, MaxCoolerHeight: 155},
+	withID: func(v pccase.Case, id int64) pccase.Case {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v pccase.Case) (int64, error) {
+		return r.SaveCase(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*pccase.Case, error) {
+		return r.GetCase(ctx, id)
+	},
+	notFound: storage.ErrCaseNotFound,
+	exists:   storage.ErrCaseAlreadyExists,
+}
+
+var coolerComponent = component[cooler.Cooler]{
+	a: cooler.Cooler{Name: "NH-D15", Type: cooler.Air, TDP: 250, Height: 165},
+	b: cooler.Cooler{Name: "Arctic Liquid Freezer II 280", Type: cooler.Liquid, TDP: 300},
+	withID: func(v cooler.Cooler, id int64) cooler.Cooler {
+		v.ID = id
+		return v
+	},
+	save: func(ctx context.Context, r storage.Repository, v cooler.Cooler) (int64, error) {
+		return r.SaveCooler(ctx, v)
+	},
+	get: func(ctx context.Context, r storage.Repository, id int64) (*cooler.Cooler, error) {
+		return r.GetCooler(ctx, id)
+	},
+	notFound: storage.ErrCoolerNotFound,
+	exists:   storage.ErrCoolerAlreadyExists,
+}
+
+// parts holds the ids of saved components, a and b of every component with
+// both, a of the others.
+type parts struct {
+	ram, ramB, cpu, cpuB, gpu, gpuB          int64
+	memory, memoryB, peripheral, peripheralB int64
+	motherboard, psu, pccase, cooler         int64
+}
+
+func saveParts(t *testing.T, r storage.Repository) parts {
+	t.Helper()
+	ctx := context.Background()
+
+	var p parts
+	must := func(id int64, err error) int64 {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("save part: %v", err)
+		}
+		return id
+	}
+
+	p.ram = must(r.SaveRAM(ctx, ramComponent.a))
+	p.ramB = must(r.SaveRAM(ctx, ramComponent.b))
+	p.cpu = must(r.SaveCPU(ctx, cpuComponent.a))
+	p.cpuB = must(r.SaveCPU(ctx, cpuComponent.b))
+	p.gpu = must(r.SaveGPU(ctx, gpuComponent.a))
+	p.gpuB = must(r.SaveGPU(ctx, gpuComponent.b))
+	p.memory = must(r.SaveMemory(ctx, memoryComponent.a))
+	p.memoryB = must(r.SaveMemory(ctx, memoryComponent.b))
+	p.peripheral = must(r.SavePeripheral(ctx, peripheralComponent.a))
+	p.peripheralB = must(r.SavePeripheral(ctx, peripheralComponent.b))
+	p.motherboard = must(r.SaveMotherboard(ctx, motherboardComponent.a))
+	p.psu = must(r.SavePSU(ctx, psuComponent.a))
+	p.pccase = must(r.SaveCase(ctx, caseComponent.a))
+	p.cooler = must(r.SaveCooler(ctx, coolerComponent.a))
+
+	return p
+}
+
+func (p parts) pc(name string) pc.PC {
+	return pc.PC{
+		Name:          name,
+		CPUID:         p.cpu,
+		RAM:           []pc.Part{{ID: p.ram, Quantity: 2, Slot: "A2"}},
+		GPU:           []pc.Part{{ID: p.gpu, Quantity: 1}},
+		Memory:        []pc.Part{{ID: p.memory, Quantity: 1, Slot: "M2_1"}, {ID: p.memoryB, Quantity: 2}},
+		MotherboardID: p.motherboard,
+		PSUID:         p.psu,
+		CaseID:        p.pccase,
+		CoolerID:      p.cooler,
+		Peripherals:   []pc.Part{{ID: p.peripheral, Quantity: 2}},
+	}
+}
+
+func testPC(t *testing.T, r storage.Repository) {
+	ctx := context.Background()
+	p := saveParts(t, r)
+
+	want := p.pc("Workstation")
+	id, err := r.SavePC(ctx, want)
+	if err != nil {
+		t.Fatalf("SavePC: %v", err)
+	}
+	want.ID = id
+
+	got, err := r.GetPC(ctx, id)
+	if err != nil {
+		t.Fatalf("GetPC: %v", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("GetPC = %+v, want %+v", *got, want)
+	}
+
+	expanded, err := r.GetExpandedPC(ctx, id)
+	if err != nil {
+		t.Fatalf("GetExpandedPC: %v", err)
+	}
+	mb := motherboardComponent.withID(motherboardComponent.a, p.motherboard)
+	ps := psuComponent.withID(psuComponent.a, p.psu)
+	pcCase := caseComponent.withID(caseComponent.a, p.pccase)
+	cool := coolerComponent.withID(coolerComponent.a, p.cooler)
+	wantExpanded := pc.Expanded{
+		ID:   id,
+		Name: "Workstation",
+		CPU:  cpuComponent.withID(cpuComponent.a, p.cpu),
+		RAM:  []pc.RAMPart{{RAM: ramComponent.withID(ramComponent.a, p.ram), Quantity: 2, Slot: "A2"}},
+		GPU:  []pc.GPUPart{{GPU: gpuComponent.withID(gpuComponent.a, p.gpu), Quantity: 1}},
+		Memory: []pc.MemoryPart{
+			{Memory: memoryComponent.withID(memoryComponent.a, p.memory), Quantity: 1, Slot: "M2_1"},
+			{Memory: memoryComponent.withID(memoryComponent.b, p.memoryB), Quantity: 2},
+		},
+		Motherboard: &mb,
+		PSU:         &ps,
+		Case:        &pcCase,
+		Cooler:      &cool,
+		Peripherals: []pc.PeripheralPart{{Peripheral: peripheralComponent.withID(peripheralComponent.a, p.peripheral), Quantity: 2}},
+	}
+	if !reflect.DeepEqual(*expanded, wantExpanded) {
+		t.Errorf("GetExpandedPC = %+v\nwant %+v", *expanded, wantExpanded)
+	}
+
+	if _, err := r.SavePC(ctx, p.pc("Workstation")); !errors.Is(err, storage.ErrPCAlreadyExists) {
+		t.Errorf("SavePC duplicate: error = %v, want %v", err, storage.ErrPCAlreadyExists)
+	}
+
+	missing := p.pc("Missing")
+	missing.GPU = []pc.Part{{ID: p.gpuB + 100, Quantity: 1}}
+	_, err = r.SavePC(ctx, missing)
+	var notFound *storage.ComponentNotFoundError
+	if !errors.As(err, &notFound) || notFound.Component != "gpu" || notFound.ID != p.gpuB+100 {
+		t.Errorf("SavePC missing gpu: error = %v, want gpu %d not found", err, p.gpuB+100)
+	}
+
+	for _, id := range []int64{id + 100} {
+		if _, err := r.GetPC(ctx, id); !errors.Is(err, storage.ErrPCNotFound) {
+			t.Errorf("GetPC missing: error = %v, want %v", err, storage.ErrPCNotFound)
+		}
+		if _, err := r.GetExpandedPC(ctx, id); !errors.Is(err, storage.ErrPCNotFound) {
+			t.Errorf("GetExpandedPC missing: error = %v, want %v", err, storage.ErrPCNotFound)
+		}
+	}
+
+	otherID, err := r.SavePC(ctx, pc.PC{Name: "Office", CPUID: p.cpuB})
+	if err != nil {
+		t.Fatalf("SavePC: %v", err)
+	}
+	expanded, err = r.GetExpandedPC(ctx, otherID)
+	if err != nil {
+		t.Fatalf("GetExpandedPC: %v", err)
+	}
+	if want := (pc.Expanded{ID: otherID, Name: "Office", CPU: cpuComponent.withID(cpuComponent.b, p.cpuB)}); !reflect.DeepEqual(*expanded, want) {
+		t.Errorf("GetExpandedPC without parts = %+v, want %+v", *expanded, want)
+	}
+
+	want.Name = "Gaming"
+	want.CPUID = p.cpuB
+	want.RAM = []pc.Part{{ID: p.ramB, Quantity: 4}}
+	want.GPU = nil
+	want.MotherboardID = 0
+	want.Peripherals = []pc.Part{{ID: p.peripheralB, Quantity: 1}}
+	if err := r.UpdatePC(ctx, want); err != nil {
+		t.Fatalf("UpdatePC: %v", err)
+	}
+	got, err = r.GetPC(ctx, id)
+	if err != nil {
+		t.Fatalf("GetPC: %v", err)
+	}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("GetPC after update = %+v, want %+v", *got, want)
+	}
+
+	clash := want
+	clash.Name = "Office"
+	if err := r.UpdatePC(ctx, clash); !errors.Is(err, storage.ErrPCAlreadyExists) {
+		t.Errorf("UpdatePC onto another name: error = %v, want %v", err, storage.ErrPCAlreadyExists)
+	}
+	clash.ID = otherID + 100
+	clash.Name = "Nowhere"
+	if err := r.UpdatePC(ctx, clash); !errors.Is(err, storage.ErrPCNotFound) {
+		t.Errorf("UpdatePC missing: error = %v, want %v", err, storage.ErrPCNotFound)
+	}
+	clash.ID = id
+	clash.CPUID = p.cpuB + 100
+	if err := r.UpdatePC(ctx, clash); !errors.Is(err, storage.ErrComponentNotFound) {
+		t.Errorf("UpdatePC missing cpu: error = %v, want %v", err, storage.ErrComponentNotFound)
+	}
+
+	if err := r.DeletePC(ctx, id); err != nil {
+		t.Fatalf("DeletePC: %v", err)
+	}
+	if _, err := r.GetPC(ctx, id); !errors.Is(err, storage.ErrPCNotFound) {
+		t.Errorf("GetPC deleted: error = %v, want %v", err, storage.ErrPCNotFound)
+	}
+	if err := r.DeletePC(ctx, id); !errors.Is(err, storage.ErrPCNotFound) {
+		t.Errorf("DeletePC deleted: error = %v, want %v", err, storage.ErrPCNotFound)
+	}
+}
+
+// testInUse checks that a component used by a pc cannot be deleted until
+// the pc is gone.
+func testInUse(t *testing.T, r storage.Repository) {
+	ctx := context.Background()
+	p := saveParts(t, r)
+
+	id, err := r.SavePC(ctx, p.pc("Workstation"))
+	if err != nil {
+		t.Fatalf("SavePC: %v", err)
+	}
+
+	deletes := []struct {
+		name  string
+		del   func() error
+		inUse error
+	}{
+		{"ram", func() error { return r.DeleteRAM(ctx, p.ram) }, storage.ErrRAMInUse},
+		{"cpu", func() error { return r.DeleteCPU(ctx, p.cpu) }, storage.ErrCPUInUse},
+		{"gpu", func() error { return r.DeleteGPU(ctx, p.gpu) }, storage.ErrGPUInUse},
+		{"memory", func() error { return r.DeleteMemory(ctx, p.memoryB) }, storage.ErrMemoryInUse},
+		{"peripheral", func() error { return r.DeletePeripheral(ctx, p.peripheral) }, storage.ErrPeripheralInUse},
+	}
+	for _, d := range deletes {
+		if err := d.del(); !errors.Is(err, d.inUse) {
+			t.Errorf("delete used %s: error = %v, want %v", d.name, err, d.inUse)
+		}
+	}
+
+	if err := r.DeletePC(ctx, id); err != nil {
+		t.Fatalf("DeletePC: %v", err)
+	}
+	for _, d := range deletes {
+		if err := d.del(); err != nil {
+			t.Errorf("delete unused %s: %v", d.name, err)
+		}
+	}
+}
+
+func testList(t *testing.T, r storage.Repository) {
+	ctx := context.Background()
+
+	var ids []int64
+	for _, v := range []ram.RAM{
+		{Name: "Kit A", MemoryType: ram.DDR5, Capacity: 32 * units.GiB, Speed: 6000, Modules: 2},
+		{Name: "Kit B", MemoryType: ram.DDR4, Capacity: 16 * units.GiB, Speed: 3200, Modules: 2},
+		{Name: "Kit C", MemoryType: ram.DDR5, Capacity: 64 * units.GiB, Speed: 5600, Modules: 2},
+		{Name: "kit d", MemoryType: ram.DDR5, Capacity: 16 * units.GiB, Speed: 4800, Modules: 1},
+	} {
+		id, err := r.SaveRAM(ctx, v)
+		if err != nil {
+			t.Fatalf("SaveRAM: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	list := func(filter storage.RAMFilter, opts storage.ListOptions) []int64 {
+		t.Helper()
+		var got []int64
+		for {
+			items, next, err := r.ListRAM(ctx, filter, opts)
+			if err != nil {
+				t.Fatalf("ListRAM(%+v, %+v): %v", filter, opts, err)
+			}
+			if len(items) > opts.PageLimit() {
+				t.Fatalf("ListRAM returned %d items, limit is %d", len(items), opts.PageLimit())
+			}
+			for _, item := range items {
+				got = append(got, item.ID)
+			}
+			if next == "" {
+				return got
+			}
+			opts.Cursor = next
+		}
+	}
+
+	gte := int64(32 * units.GiB)
+	tests := []struct {
+		name   string
+		filter storage.RAMFilter
+		opts   storage.ListOptions
+		want   []int64
+	}{
+		{"all", storage.RAMFilter{}, storage.ListOptions{}, ids},
+		{"pages", storage.RAMFilter{}, storage.ListOptions{Limit: 1}, ids},
+		{"desc", storage.RAMFilter{}, storage.ListOptions{Limit: 3, Desc: true}, []int64{ids[3], ids[2], ids[1], ids[0]}},
+		{"sort", storage.RAMFilter{}, storage.ListOptions{Limit: 2, SortBy: "capacity"}, []int64{ids[1], ids[3], ids[0], ids[2]}},
+		{"sort desc", storage.RAMFilter{}, storage.ListOptions{Limit: 2, SortBy: "capacity", Desc: true}, []int64{ids[2], ids[0], ids[3], ids[1]}},
+		{"memory type", storage.RAMFilter{MemoryType: "ddr5"}, storage.ListOptions{}, []int64{ids[0], ids[2], ids[3]}},
+		{"name", storage.RAMFilter{Name: "KIT"}, storage.ListOptions{Limit: 3}, ids},
+		{"capacity", storage.RAMFilter{Capacity: storage.Int64Filter{GTE: &gte}}, storage.ListOptions{Limit: 1}, []int64{ids[0], ids[2]}},
+		{"none", storage.RAMFilter{Name: "%"}, storage.ListOptions{}, nil},
+	}
+	for _, tt := range tests {
+		if got := list(tt.filter, tt.opts); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ListRAM ids = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, _, err := r.ListRAM(ctx, storage.RAMFilter{}, storage.ListOptions{SortBy: "nope"}); !errors.Is(err, storage.ErrInvalidSort) {
+		t.Errorf("ListRAM bad sort: error = %v, want %v", err, storage.ErrInvalidSort)
+	}
+	if _, _, err := r.ListRAM(ctx, storage.RAMFilter{}, storage.ListOptions{Cursor: "nope"}); !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Errorf("ListRAM bad cursor: error = %v, want %v", err, storage.ErrInvalidCursor)
+	}
+
+	cpuID, err := r.SaveCPU(ctx, cpuComponent.a)
+	if err != nil {
+		t.Fatalf("SaveCPU: %v", err)
+	}
+	pcID, err := r.SavePC(ctx, pc.PC{Name: "Box", CPUID: cpuID, RAM: []pc.Part{{ID: ids[2], Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("SavePC: %v", err)
+	}
+	if _, err := r.SavePC(ctx, pc.PC{Name: "Other box", CPUID: cpuID}); err != nil {
+		t.Fatalf("SavePC: %v", err)
+	}
+	pcs, next, err := r.ListPC(ctx, storage.PCFilter{RAMID: &ids[2]}, storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListPC: %v", err)
+	}
+	if len(pcs) != 1 || pcs[0].ID != pcID || len(pcs[0].RAM) != 1 || next != "" {
+		t.Errorf("ListPC by ram = %+v, next %q, want pc %d alone", pcs, next, pcID)
+	}
+}
+
+func testBuild(t *testing.T, r storage.Repository) {
+	ctx := context.Background()
+
+	cpuID, err := r.SaveCPU(ctx, cpuComponent.a)
+	if err != nil {
+		t.Fatalf("SaveCPU: %v", err)
+	}
+
+	b := pc.Build{
+		Name:        "Build",
+		CPU:         cpu.CPU{ID: cpuID},
+		RAM:         []pc.RAMPart{{RAM: ramComponent.a, Quantity: 2}},
+		GPU:         []pc.GPUPart{{GPU: gpuComponent.a, Quantity: 1}},
+		Memory:      []pc.MemoryPart{{Memory: memoryComponent.a, Quantity: 1, Slot: "M2_1"}},
+		Motherboard: &motherboardComponent.a,
+		PSU:         &psuComponent.a,
+	}
+	saved, err := r.SaveBuild(ctx, b)
+	if err != nil {
+		t.Fatalf("SaveBuild: %v", err)
+	}
+	if saved.CPUID != cpuID || len(saved.RAM) != 1 || saved.RAM[0].Quantity != 2 || saved.MotherboardID == 0 || saved.PSUID == 0 {
+		t.Errorf("SaveBuild = %+v", saved)
+	}
+
+	got, err := r.GetPC(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetPC: %v", err)
+	}
+	if !reflect.DeepEqual(got, saved) {
+		t.Errorf("GetPC = %+v, want the saved build %+v", got, saved)
+	}
+	savedRAM, err := r.GetRAM(ctx, saved.RAM[0].ID)
+	if err != nil {
+		t.Fatalf("GetRAM: %v", err)
+	}
+	if want := ramComponent.withID(ramComponent.a, saved.RAM[0].ID); !reflect.DeepEqual(*savedRAM, want) {
+		t.Errorf("GetRAM = %+v, want %+v", *savedRAM, want)
+	}
+
+	// the name is taken, nothing of the build is kept
+	again := b
+	again.RAM = []pc.RAMPart{{RAM: ramComponent.b, Quantity: 1}}
+	if _, err := r.SaveBuild(ctx, again); !errors.Is(err, storage.ErrPCAlreadyExists) {
+		t.Errorf("SaveBuild duplicate name: error = %v, want %v", err, storage.ErrPCAlreadyExists)
+	}
+	items, _, err := r.ListRAM(ctx, storage.RAMFilter{Name: ramComponent.b.Name}, storage.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListRAM: %v", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("failed SaveBuild kept ram %+v", items)
+	}
+
+	// the ram is new, the other components exist now and are reused
+	again.Name = "Build 2"
+	again.CPU = cpuComponent.a
+	reused, err := r.SaveBuild(ctx, again)
+	if err != nil {
+		t.Fatalf("SaveBuild existing components: %v", err)
+	}
+	if reused.CPUID != cpuID || reused.GPU[0].ID != saved.GPU[0].ID || reused.Memory[0].ID != saved.Memory[0].ID ||
+		reused.MotherboardID != saved.MotherboardID || reused.PSUID != saved.PSUID {
+		t.Errorf("SaveBuild existing components = %+v, want the ids of %+v", reused, saved)
+	}
+	if reused.RAM[0].ID == saved.RAM[0].ID {
+		t.Errorf("SaveBuild new ram reused ram %d", saved.RAM[0].ID)
+	}
+
+	again.Name = "Build 3"
+	again.CPU = cpu.CPU{ID: cpuID + 100}
+	var notFound *storage.ComponentNotFoundError
+	if _, err := r.SaveBuild(ctx, again); !errors.As(err, &notFound) || notFound.Component != "cpu" {
+		t.Errorf("SaveBuild missing cpu: error = %v, want cpu not found", err)
+	}
+}